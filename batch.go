@@ -0,0 +1,149 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BatchConstraint is a constraint across the items of a batch, checked by
+// ValidateAll. See Unique and Same.
+type BatchConstraint struct {
+	rule  string
+	field string
+}
+
+// Unique requires field, a dotted path like "SKU" or "Product.SKU", to be
+// unique across the items of a batch. Every item repeating the value of
+// an earlier item fails.
+func Unique(field string) BatchConstraint {
+	return BatchConstraint{"unique", field}
+}
+
+// Same requires field, a dotted path like "CurrencyCode", to hold the same
+// value in all the items of a batch. Every item differing from the first
+// item fails.
+func Same(field string) BatchConstraint {
+	return BatchConstraint{"same", field}
+}
+
+// ValidateAll validates a batch with the package default Validator.
+func ValidateAll(items any, constraints []BatchConstraint, opts ...Option) error {
+	return defaultValidator.ValidateAll(items, constraints, opts...)
+}
+
+// ValidateAll validates items, a slice or an array of structs, and then
+// checks constraints across them. Errors locate items by index, e.g.
+// [3].SKU. Options given here are layered over the Validator's defaults
+// and affect only this call, as with Validate.
+func (v *Validator) ValidateAll(items any, constraints []BatchConstraint, opts ...Option) error {
+	vVal := indirect(reflect.ValueOf(items))
+	if vVal.Kind() != reflect.Slice && vVal.Kind() != reflect.Array {
+		return ErrNotStruct
+	}
+	for i := 0; i < vVal.Len(); i++ {
+		if indirect(vVal.Index(i)).Kind() != reflect.Struct {
+			return ErrNotStruct
+		}
+	}
+	c := &validation{opts: v.opts.with(opts), cache: v.cache, root: vVal, ctx: context.Background()}
+	var err error
+	if c.only, err = parseSelectors(c.opts.only); err != nil {
+		return err
+	}
+	if c.except, err = parseSelectors(c.opts.except); err != nil {
+		return err
+	}
+	valErrs, err := c.validateImpl(vVal, nil, "", nil)
+	if err == nil {
+		for _, bc := range constraints {
+			var bcErrs ValidationErrors
+			if bcErrs, err = bc.check(vVal); err != nil {
+				break
+			}
+			for i := range bcErrs {
+				bcErrs[i].DocURL = c.opts.docURL(bcErrs[i], "")
+			}
+			valErrs = append(valErrs, bcErrs...)
+		}
+	}
+	return Result{errs: c.capped(valErrs), err: err}.Err()
+}
+
+// check returns an error for each item of items breaking the constraint.
+func (bc BatchConstraint) check(items reflect.Value) (valErrs ValidationErrors, err error) {
+	var fieldPath string
+	names := strings.Split(bc.field, ".")
+	for _, name := range names {
+		fieldPath += PathSegment{Field: name}.String()
+	}
+	seen := make(map[string]bool)
+	var first string
+	for i := 0; i < items.Len(); i++ {
+		fv, err := lookupField(items.Index(i), bc.field)
+		if err != nil {
+			return nil, err
+		}
+		key := formatMapKey(fv)
+		var ok bool
+		switch bc.rule {
+		case "unique":
+			ok = !seen[key]
+			seen[key] = true
+		case "same":
+			if i == 0 {
+				first = key
+			}
+			ok = key == first
+		}
+		if !ok {
+			path := PathSegment{Key: FormatMapKey(i)}.String() + fieldPath
+			valErr := FieldError{
+				StructField: names[len(names)-1],
+				Path:        path,
+				Rule:        bc.rule,
+				Code:        ruleCode(bc.rule),
+				Value:       interfaceOf(fv),
+			}
+			valErr.Err = failure(valErr, "")
+			valErrs = append(valErrs, valErr)
+		}
+	}
+	return valErrs, nil
+}
+
+// uniqueByRule requires the elements of a slice or an array of structs to
+// differ by a field, a dotted path like "SKU" or "Product.SKU". Every
+// element repeating the value of an earlier element fails, e.g. at
+// .Items[3]. Nil elements are skipped.
+var uniqueByRule = rule{
+	assertElems: func(v reflect.Value, p param) ([]int, error) {
+		v = indirect(v)
+		switch v.Kind() {
+		case reflect.Invalid:
+			return nil, nil
+		case reflect.Slice, reflect.Array:
+		default:
+			return nil, fmt.Errorf("unique_by: unsupported type %s", v.Type())
+		}
+		var failed []int
+		seen := make(map[string]bool)
+		for i := 0; i < v.Len(); i++ {
+			elem := indirect(v.Index(i))
+			if !elem.IsValid() {
+				continue
+			}
+			fv, err := lookupField(elem, p.val)
+			if err != nil {
+				return nil, fmt.Errorf("unique_by: %v", err)
+			}
+			key := formatMapKey(fv)
+			if seen[key] {
+				failed = append(failed, i)
+			}
+			seen[key] = true
+		}
+		return failed, nil
+	},
+}
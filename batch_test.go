@@ -0,0 +1,79 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAll(t *testing.T) {
+	type product struct {
+		SKU string
+	}
+	type line struct {
+		Product      product
+		CurrencyCode string `validate:"len:3"`
+		Qty          int    `validate:"min:1"`
+	}
+	batch := []line{
+		{product{"A1"}, "EUR", 1},
+		{product{"B2"}, "EUR", 2},
+		{product{"C3"}, "EUR", 3},
+	}
+	constraints := []BatchConstraint{Unique("Product.SKU"), Same("CurrencyCode")}
+	assert.NoError(t, ValidateAll(batch, constraints))
+
+	batch = []line{
+		{product{"A1"}, "EUR", 1},
+		{product{"B2"}, "USD", 0},
+		{product{"A1"}, "EUR", 1},
+		{product{"A1"}, "GBP", 1},
+	}
+	err := ValidateAll(&batch, constraints)
+	assert.EqualError(t, err, `[1].Qty: validation failed for "min" tag`+
+		`; [2].Product.SKU: validation failed for "unique" tag`+
+		`; [3].Product.SKU: validation failed for "unique" tag`+
+		`; [1].CurrencyCode: validation failed for "same" tag`+
+		`; [3].CurrencyCode: validation failed for "same" tag`)
+
+	// Options apply to the call.
+	err = ValidateAll(batch, constraints, WithMaxErrors(2))
+	assert.EqualError(t, err, `[1].Qty: validation failed for "min" tag`+
+		`; [2].Product.SKU: validation failed for "unique" tag`)
+	err = New().ValidateAll(batch, nil, WithJSONPointerPaths())
+	assert.EqualError(t, err, `/1/Qty: validation failed for "min" tag`)
+
+	assert.NoError(t, ValidateAll([]*line{}, constraints))
+	assert.Equal(t, ErrNotStruct, ValidateAll(line{}, nil))
+	assert.Equal(t, ErrNotStruct, ValidateAll([]int{1}, nil))
+	assert.EqualError(t, ValidateAll(batch, []BatchConstraint{Unique("Price")}), `field reference "Price": unknown field "Price"`)
+}
+
+func TestUniqueBy(t *testing.T) {
+	type Product struct {
+		SKU string
+	}
+	type LineItem struct {
+		SKU     string
+		Product Product
+	}
+	type Order struct {
+		Items    []LineItem  `validate:"unique_by:SKU"`
+		Products []*LineItem `validate:"unique_by:Product.SKU"`
+	}
+	assert.NoError(t, Validate(Order{
+		Items:    []LineItem{{SKU: "a"}, {SKU: "b"}},
+		Products: []*LineItem{{Product: Product{"a"}}, nil, {Product: Product{"b"}}},
+	}))
+	assert.EqualError(t, Validate(Order{
+		Items:    []LineItem{{SKU: "a"}, {SKU: "b"}, {SKU: "a"}, {SKU: "a"}},
+		Products: []*LineItem{{Product: Product{"a"}}, {Product: Product{"a"}}},
+	}), `.Items[2]: validation failed for "unique_by" tag`+
+		`; .Items[3]: validation failed for "unique_by" tag`+
+		`; .Products[1]: validation failed for "unique_by" tag`)
+
+	type Bad struct {
+		Items []LineItem `validate:"unique_by:Code"`
+	}
+	assert.ErrorContains(t, Validate(Bad{Items: []LineItem{{}}}), `unique_by: field reference "Code": unknown field "Code"`)
+}
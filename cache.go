@@ -0,0 +1,357 @@
+package validate
+
+import (
+	"container/list"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// planKey identifies a struct plan, or the parsed tag of values given to
+// Var. Plans depend on the tag syntax and key, so it is part of the key.
+// reflect.Type values are unique per type, including types built with
+// reflect.StructOf: equal field lists yield the same Type, while fields
+// differing only by tag yield distinct ones.
+type planKey struct {
+	typ reflect.Type
+	syntax
+	// tag is the tag given to Var, whose plans have no typ.
+	tag string
+}
+
+// structPlan holds the parsed validate tags of a struct type's fields,
+// indexed like the fields themselves.
+type structPlan struct {
+	typ    reflect.Type
+	fields []fieldPlan
+	// flat is set on the plans of flat structs, whose fast fields are
+	// checked by the fast path, see newFastFields.
+	flat bool
+	fast []fastField
+}
+
+type fieldPlan struct {
+	name       string
+	unexported bool
+	tag        fieldTag
+	// desc is the desc tag of the field, describing it in errors.
+	desc string
+	// doc is the doc tag of the field, see WithDocURL.
+	doc string
+	// whole is set on embedded structs. Their rules apply to the embedded
+	// value as a whole instead of being passed down to its fields.
+	whole bool
+	// plan, set on embedded struct fields with rules registered for their
+	// promoted fields, is the plan to validate the field with.
+	plan *structPlan
+}
+
+// fieldTag is the parsed form of a single validate tag. A tag which failed
+// to parse keeps its error until a value is actually validated against it.
+type fieldTag struct {
+	rules []tagRule
+	// keys and values hold the rules of the keys: and values: sections
+	// of a map tag.
+	keys, values []tagRule
+	// msg replaces the messages of the failures of the tag when set by
+	// the msg pseudo-rule.
+	msg string
+	err error
+}
+
+// sectioned reports whether the tag has keys: or values: sections, making
+// its other rules apply to the map itself rather than to its values.
+func (t *fieldTag) sectioned() bool {
+	return t.keys != nil || t.values != nil
+}
+
+// pathName returns the name of the i-th field in error paths.
+func (p *structPlan) pathName(i int, naming FieldNameFunc) string {
+	if naming != nil {
+		if name := naming(p.typ.Field(i)); name != "" {
+			return name
+		}
+	}
+	return p.fields[i].name
+}
+
+// describe sets the description of the field on the errors of the values
+// it holds which have none.
+func (f *fieldPlan) describe(errs ValidationErrors) ValidationErrors {
+	if f.desc == "" {
+		return errs
+	}
+	for i := range errs {
+		if errs[i].Description == "" {
+			errs[i].Description = f.desc
+		}
+	}
+	return errs
+}
+
+// merge returns t with the rules of over layered on top: rules of over
+// replace the first rule of t with the same name and role, or are
+// appended.
+func (t fieldTag) merge(over fieldTag) fieldTag {
+	if t.err != nil {
+		return t
+	}
+	if over.err != nil {
+		return over
+	}
+	t.rules = mergeRules(t.rules, over.rules)
+	t.keys = mergeRules(t.keys, over.keys)
+	t.values = mergeRules(t.values, over.values)
+	if over.msg != "" {
+		t.msg = over.msg
+	}
+	return t
+}
+
+func mergeRules(base, over []tagRule) []tagRule {
+	res := append([]tagRule(nil), base...)
+	for _, tr := range over {
+		i := 0
+		for i < len(base) && (base[i].key != tr.key || base[i].role != tr.role) {
+			i++
+		}
+		if i < len(base) {
+			res[i] = tr
+		} else {
+			res = append(res, tr)
+		}
+	}
+	return res
+}
+
+// empty reports whether the tag has nothing to check.
+func (t *fieldTag) empty() bool {
+	return t.rules == nil && !t.sectioned() && t.err == nil
+}
+
+// planCache is a concurrency safe cache of struct plans. When maxEntries
+// is positive the least recently used plans are evicted beyond it.
+type planCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	lru        *list.List
+	plans      map[planKey]*list.Element
+	// structs is a copy of the struct plans of unbounded caches by type,
+	// replaced whenever they change, so that finding a plan takes no lock.
+	// Bounded caches track recency under the lock instead.
+	structs atomic.Pointer[map[reflect.Type][]*planEntry]
+	// registered holds the tags registered with RegisterRules by struct
+	// type and field name. It is replaced rather than modified.
+	registered map[reflect.Type]map[string]string
+	// gen is incremented whenever registered changes, so that plans built
+	// from previous registrations are not cached.
+	gen uint64
+}
+
+type planEntry struct {
+	key  planKey
+	plan *structPlan
+	tag  fieldTag
+	// gen is the generation of the registrations plan was built from.
+	gen uint64
+}
+
+func newPlanCache(maxEntries int) *planCache {
+	c := &planCache{
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		plans:      make(map[planKey]*list.Element),
+	}
+	c.structs.Store(&map[reflect.Type][]*planEntry{})
+	return c
+}
+
+// plan returns the plan of struct type t, building it on first use.
+func (c *planCache) plan(t reflect.Type, o *options) *structPlan {
+	key := planKey{typ: t, syntax: o.syntax}
+	if e := c.get(key); e != nil {
+		return e.plan
+	}
+	c.mu.Lock()
+	registered, gen := c.registered, c.gen
+	c.mu.Unlock()
+	return c.add(&planEntry{key: key, plan: newStructPlan(t, o, registered, nil), gen: gen}).plan
+}
+
+// varTag returns the parsed form of tag given to Var, parsing it on first
+// use. Parsed tags share the room of the cache with struct plans.
+func (c *planCache) varTag(tag string, o *options) fieldTag {
+	key := planKey{syntax: o.syntax, tag: tag}
+	if e := c.get(key); e != nil {
+		return e.tag
+	}
+	return c.add(&planEntry{key: key, tag: newFieldTag(tag, nil, o)}).tag
+}
+
+func (c *planCache) get(key planKey) *planEntry {
+	if key.typ != nil {
+		for _, e := range (*c.structs.Load())[key.typ] {
+			if e.key == key {
+				return e
+			}
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.plans[key]; ok {
+		c.lru.MoveToFront(e)
+		return e.Value.(*planEntry)
+	}
+	return nil
+}
+
+// add stores entry unless an entry for its key was stored concurrently,
+// and returns the stored entry. Plans built from registrations changed
+// since are returned without being stored.
+func (c *planCache) add(entry *planEntry) *planEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry.plan != nil && entry.gen != c.gen {
+		return entry
+	}
+	if e, ok := c.plans[entry.key]; ok {
+		c.lru.MoveToFront(e)
+		return e.Value.(*planEntry)
+	}
+	c.plans[entry.key] = c.lru.PushFront(entry)
+	if c.maxEntries <= 0 && entry.plan != nil {
+		structs := make(map[reflect.Type][]*planEntry, len(*c.structs.Load())+1)
+		for typ, entries := range *c.structs.Load() {
+			structs[typ] = entries
+		}
+		typ := entry.key.typ
+		structs[typ] = append(structs[typ][:len(structs[typ]):len(structs[typ])], entry)
+		c.structs.Store(&structs)
+	}
+	if c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.plans, oldest.Value.(*planEntry).key)
+	}
+	return entry
+}
+
+func (c *planCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *planCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clearLocked()
+}
+
+func (c *planCache) clearLocked() {
+	c.lru.Init()
+	c.plans = make(map[planKey]*list.Element)
+	c.structs.Store(&map[reflect.Type][]*planEntry{})
+}
+
+// newStructPlan builds the plan of struct type t. Tags registered for a
+// field are layered over its struct tags, and overrides, registered for
+// promoted fields by the types embedding t, over both.
+func newStructPlan(t reflect.Type, o *options, registered map[reflect.Type]map[string]string, overrides map[string][]string) *structPlan {
+	p := &structPlan{typ: t, fields: make([]fieldPlan, t.NumField())}
+	for i := range p.fields {
+		field := t.Field(i)
+		p.fields[i].name = field.Name
+		p.fields[i].desc = field.Tag.Get("desc")
+		p.fields[i].doc = field.Tag.Get("doc")
+		if ft := indirectType(field.Type); field.Anonymous && ft.Kind() == reflect.Struct && !isScalar(ft, o) {
+			p.fields[i].whole = true
+		}
+		for _, key := range strings.Fields(o.tagKeys) {
+			tag, tagOk := field.Tag.Lookup(key)
+			if !tagOk {
+				continue
+			}
+			p.fields[i].unexported = !field.IsExported()
+			if len(tag) > 0 {
+				p.fields[i].tag = p.fields[i].tag.merge(newFieldTag(tag, t, o))
+			}
+		}
+		tags := overrides[field.Name]
+		if tag, ok := registered[t][field.Name]; ok {
+			tags = append([]string{tag}, tags...)
+		}
+		for _, tag := range tags {
+			p.fields[i].unexported = !field.IsExported()
+			p.fields[i].tag = p.fields[i].tag.merge(newFieldTag(tag, t, o))
+		}
+		if promoted := promotedRules(t, i, registered[t], overrides); promoted != nil {
+			p.fields[i].plan = newStructPlan(indirectType(field.Type), o, registered, promoted)
+		}
+	}
+	p.fast, p.flat = newFastFields(p, o)
+	return p
+}
+
+// newFieldTag parses tag, declared on a field of owner, and compiles the
+// parameters of its rules, or else parses their elements once for all.
+func newFieldTag(tag string, owner reflect.Type, o *options) fieldTag {
+	tagRules, err := parseTag(tag, o)
+	if err != nil {
+		return fieldTag{err: err}
+	}
+	var res fieldTag
+	var groups *tagRule
+	for _, tr := range tagRules {
+		if tr.key == roleTag {
+			if tr, err = lexRoleRule(tag, tr, o); err != nil {
+				return fieldTag{err: err}
+			}
+		}
+		section := &res.rules
+		switch tr.key {
+		case keysTag:
+			section = &res.keys
+		case valuesTag:
+			section = &res.values
+		case groupsTag:
+			g := tr
+			groups = &g
+		case msgTag:
+			res.msg = tr.param.val
+			continue
+		}
+		if warn := tr.key == warnTag; warn || section != &res.rules {
+			// The parameter of a section or of warn is the rule it holds.
+			start := tr.pos + len(tr.key) + utf8.RuneLen(o.kvSep)
+			role := tr.role
+			if tr, err = lexRule(tag, start, start+len(tr.param.val), o); err != nil {
+				return fieldTag{err: err}
+			}
+			tr.warn, tr.role = warn, role
+		}
+		// Data references are compiled once resolved, see param.resolve.
+		if _, ref := dataRef(tr.param.val); !ref {
+			if rule, exists := rules[tr.key]; exists && rule.compile != nil {
+				if tr.param.compiled, err = rule.compile(tr.param, owner); err != nil {
+					return fieldTag{err: err}
+				}
+			} else if exists {
+				tr.param = tr.param.parsed()
+			}
+		}
+		*section = append(*section, tr)
+	}
+	// Sections are restricted to the groups of the tag they belong to.
+	if groups != nil {
+		if res.keys != nil {
+			res.keys = append(res.keys, *groups)
+		}
+		if res.values != nil {
+			res.values = append(res.values, *groups)
+		}
+	}
+	return res
+}
@@ -0,0 +1,99 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanCacheEviction(t *testing.T) {
+	type a struct {
+		A int `validate:"min:1"`
+	}
+	type b struct {
+		B int `validate:"min:1"`
+	}
+	type c struct {
+		C int `validate:"min:1"`
+	}
+	v := New(WithCacheSize(2))
+	key := func(s any) planKey {
+		return planKey{typ: reflect.TypeOf(s), syntax: v.opts.syntax}
+	}
+
+	assert.NoError(t, v.Validate(a{1}))
+	assert.NoError(t, v.Validate(b{1}))
+	assert.NoError(t, v.Validate(a{1}))
+	assert.NoError(t, v.Validate(c{1}))
+
+	assert.Equal(t, 2, v.cache.len())
+	assert.NotNil(t, v.cache.get(key(a{})))
+	assert.Nil(t, v.cache.get(key(b{})), "least recently used plan should be evicted")
+	assert.NotNil(t, v.cache.get(key(c{})))
+
+	v.ClearCache()
+	assert.Equal(t, 0, v.cache.len())
+	assert.Error(t, v.Validate(a{}))
+	assert.Equal(t, 1, v.cache.len())
+}
+
+func TestPlanCacheLockFree(t *testing.T) {
+	type a struct {
+		A int `validate:"min:1"`
+	}
+	key := planKey{typ: reflect.TypeOf(a{}), syntax: defaultOptions().syntax}
+	v := New()
+	assert.NoError(t, v.Validate(a{1}))
+	// Plans of unbounded caches are found without taking the lock.
+	v.cache.mu.Lock()
+	assert.NotNil(t, v.cache.get(key))
+	v.cache.mu.Unlock()
+
+	v.ClearCache()
+	assert.Nil(t, v.cache.get(key))
+	bounded := New(WithCacheSize(1))
+	assert.NoError(t, bounded.Validate(a{1}))
+	assert.Empty(t, *bounded.cache.structs.Load())
+	assert.NotNil(t, bounded.cache.get(key))
+}
+
+func TestPlanCacheStructOf(t *testing.T) {
+	newType := func(tag string) reflect.Type {
+		return reflect.StructOf([]reflect.StructField{
+			{Name: "Name", Type: reflect.TypeOf(""), Tag: reflect.StructTag(tag)},
+			{Name: "Tags", Type: reflect.TypeOf([]int{}), Tag: `validate:"in:1,2"`},
+		})
+	}
+	short, long := newType(`validate:"max:3"`), newType(`validate:"min:5"`)
+	assert.Same(t, short, newType(`validate:"max:3"`), "identical StructOf calls yield the same type")
+
+	v := New()
+	newValue := func(typ reflect.Type, name string, tags ...int) any {
+		val := reflect.New(typ).Elem()
+		val.Field(0).SetString(name)
+		val.Field(1).Set(reflect.ValueOf(tags))
+		return val.Interface()
+	}
+	assert.NoError(t, v.Validate(newValue(short, "abc", 1, 2)))
+	assert.NoError(t, v.Validate(newValue(long, "abcdef", 2)))
+	assert.EqualError(t, v.Validate(newValue(short, "abcdef")), `.Name: validation failed for "max" tag`)
+	assert.EqualError(t, v.Validate(newValue(long, "abc", 3)),
+		`.Name: validation failed for "min" tag; .Tags[0]: validation failed for "in" tag`)
+	assert.Equal(t, 2, v.cache.len())
+}
+
+func TestPlanCacheVarTags(t *testing.T) {
+	v := New(WithCacheSize(2))
+	assert.NoError(t, v.Var(5, "min:1;max:10"))
+	assert.Error(t, v.Var(50, "min:1;max:10"))
+	assert.Equal(t, 1, v.cache.len())
+	assert.NotNil(t, v.cache.get(planKey{syntax: v.opts.syntax, tag: "min:1;max:10"}))
+
+	// Tags are parsed with the syntax of the call.
+	assert.NoError(t, v.Var(5, "min=1,max=10", WithPlaygroundSyntax()))
+	assert.Equal(t, 2, v.cache.len())
+	assert.ErrorIs(t, v.Var(5, "min=1,max=10"), ErrInvalidValidatorSyntax)
+	assert.ErrorIs(t, v.Var(5, "min=1,max=10"), ErrInvalidValidatorSyntax)
+	assert.Equal(t, 2, v.cache.len())
+}
@@ -0,0 +1,352 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Compatibility tells how the rules of a field changed between two
+// versions of a struct.
+type Compatibility int
+
+const (
+	// Unchanged means the rules accept the same values.
+	Unchanged Compatibility = iota
+	// Looser means the new rules accept every value accepted before.
+	Looser
+	// Stricter means the new rules may reject values accepted before.
+	Stricter
+	// Changed means the new rules accept some new values and may reject
+	// some values accepted before.
+	Changed
+)
+
+func (c Compatibility) String() string {
+	switch c {
+	case Looser:
+		return "looser"
+	case Stricter:
+		return "stricter"
+	case Changed:
+		return "changed"
+	}
+	return "unchanged"
+}
+
+// Breaking reports whether values accepted before may be rejected.
+func (c Compatibility) Breaking() bool {
+	return c == Stricter || c == Changed
+}
+
+// and combines the compatibilities of two changes made together.
+func (c Compatibility) and(other Compatibility) Compatibility {
+	switch {
+	case c == other || other == Unchanged:
+		return c
+	case c == Unchanged:
+		return other
+	}
+	return Changed
+}
+
+// FieldChange describes how the rules of a field changed.
+type FieldChange struct {
+	// Path locates the field, e.g. .Address.City.
+	Path string
+	Compatibility
+	Rules []RuleChange
+}
+
+// RuleChange describes how a rule of a field changed. Old and New are the
+// rule as written, e.g. "min:3", and empty when the rule is absent. Rules
+// of the keys: and values: sections of map tags are named with the section
+// as a prefix, e.g. "keys:len", and rules written for a role with the
+// role, e.g. "role:admin=max".
+type RuleChange struct {
+	Rule     string
+	Old, New string
+	Compatibility
+}
+
+// CompareRules compares the rules of two versions of a struct type using
+// the package default Validator.
+func CompareRules(old, new any, opts ...Option) ([]FieldChange, error) {
+	return defaultValidator.CompareRules(old, new, opts...)
+}
+
+// CompareRules compares the rules of struct types old and new, which may
+// be pointers, field by field, and returns the fields whose rules changed.
+// Fields are matched by name, and nested structs are compared likewise.
+// Rules added to a field make it stricter and removed rules make it looser,
+// except for omitempty and groups which do the opposite. Warning rules like
+// deprecated and rules written with the warn modifier accept every value
+// and are ignored. Changed min, max, gt, lt and in parameters are compared
+// by value; other changed parameters are reported as Changed. A change is
+// breaking when clients sending values accepted by old may see them
+// rejected by new, see Compatibility.Breaking.
+func (v *Validator) CompareRules(old, new any, opts ...Option) ([]FieldChange, error) {
+	o := v.opts.with(opts)
+	oldType, newType := reflect.TypeOf(old), reflect.TypeOf(new)
+	if oldType == nil || newType == nil ||
+		indirectType(oldType).Kind() != reflect.Struct || indirectType(newType).Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+	cmp := &planComparison{opts: &o, cache: v.cache, seen: make(map[[2]reflect.Type]bool)}
+	if err := cmp.plans(v.cache.plan(indirectType(oldType), &o), v.cache.plan(indirectType(newType), &o), ""); err != nil {
+		return nil, err
+	}
+	return cmp.changes, nil
+}
+
+// planComparison holds the state of a CompareRules call.
+type planComparison struct {
+	opts    *options
+	cache   *planCache
+	changes []FieldChange
+	// seen holds the pairs of types compared, to stop at recursive types.
+	seen map[[2]reflect.Type]bool
+}
+
+func (c *planComparison) plans(old, new *structPlan, callstack string) error {
+	if c.seen[[2]reflect.Type{old.typ, new.typ}] {
+		return nil
+	}
+	c.seen[[2]reflect.Type{old.typ, new.typ}] = true
+	for i := range old.fields {
+		oldField := &old.fields[i]
+		j := fieldIndex(new, oldField.name)
+		if j < 0 {
+			if err := c.fields(oldField, nil, callstack); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.fields(oldField, &new.fields[j], callstack); err != nil {
+			return err
+		}
+		oldType, newType := old.typ.Field(i).Type, new.typ.Field(j).Type
+		if oldStruct, newStruct := elemStruct(oldType, c.opts), elemStruct(newType, c.opts); oldStruct != nil && newStruct != nil {
+			oldPlan, newPlan := oldField.plan, new.fields[j].plan
+			if oldPlan == nil {
+				oldPlan = c.cache.plan(oldStruct, c.opts)
+			}
+			if newPlan == nil {
+				newPlan = c.cache.plan(newStruct, c.opts)
+			}
+			if err := c.plans(oldPlan, newPlan, fieldPath(callstack, oldField.name)); err != nil {
+				return err
+			}
+		}
+	}
+	for j := range new.fields {
+		if fieldIndex(old, new.fields[j].name) < 0 {
+			if err := c.fields(nil, &new.fields[j], callstack); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fields records the changes between the rules of the old and new
+// versions of a field, either of which may be nil.
+func (c *planComparison) fields(old, new *fieldPlan, callstack string) error {
+	var oldTag, newTag fieldTag
+	name := ""
+	if old != nil {
+		oldTag, name = old.tag, old.name
+	}
+	if new != nil {
+		newTag, name = new.tag, new.name
+	}
+	if oldTag.err != nil {
+		return oldTag.err
+	}
+	if newTag.err != nil {
+		return newTag.err
+	}
+	change := FieldChange{Path: fieldPath(callstack, name)}
+	for _, section := range []struct {
+		prefix   string
+		old, new []tagRule
+	}{
+		{"", oldTag.rules, newTag.rules},
+		{keysTag + ":", oldTag.keys, newTag.keys},
+		{valuesTag + ":", oldTag.values, newTag.values},
+	} {
+		change.Rules = append(change.Rules, compareRules(section.prefix, section.old, section.new, c.opts)...)
+	}
+	for _, rc := range change.Rules {
+		change.Compatibility = change.Compatibility.and(rc.Compatibility)
+	}
+	if len(change.Rules) > 0 {
+		c.changes = append(c.changes, change)
+	}
+	return nil
+}
+
+// compareRules returns the changes between two lists of rules, matched by
+// name, but for warning rules.
+func compareRules(prefix string, old, new []tagRule, o *options) []RuleChange {
+	var res []RuleChange
+	for _, oldRule := range old {
+		if rules[oldRule.key].warn || oldRule.warn {
+			continue
+		}
+		rc := RuleChange{Rule: prefix + ruleName(oldRule, o), Old: ruleString(oldRule, o)}
+		if newRule, ok := findRule(new, oldRule.key, oldRule.role); ok {
+			rc.New = ruleString(newRule, o)
+			rc.Compatibility = compareParams(oldRule.key, oldRule.param, newRule.param)
+		} else {
+			rc.Compatibility = Looser
+			if relaxing(oldRule.key) {
+				rc.Compatibility = Stricter
+			}
+		}
+		if rc.Compatibility != Unchanged {
+			res = append(res, rc)
+		}
+	}
+	for _, newRule := range new {
+		if _, ok := findRule(old, newRule.key, newRule.role); !ok && !rules[newRule.key].warn && !newRule.warn {
+			rc := RuleChange{Rule: prefix + ruleName(newRule, o), New: ruleString(newRule, o), Compatibility: Stricter}
+			if relaxing(newRule.key) {
+				rc.Compatibility = Looser
+			}
+			res = append(res, rc)
+		}
+	}
+	return res
+}
+
+// relaxing reports whether the rule named key makes the other rules of a
+// tag apply to fewer values.
+func relaxing(key string) bool {
+	return key == omitemptyTag || key == groupsTag
+}
+
+// compareParams compares the old and new parameters of a rule.
+func compareParams(key string, old, new param) Compatibility {
+	if old.val == new.val {
+		return Unchanged
+	}
+	switch key {
+	case "min", "max", "gt", "lt":
+		oldBound, err1 := strconv.ParseFloat(old.val, 64)
+		newBound, err2 := strconv.ParseFloat(new.val, 64)
+		switch {
+		case err1 != nil || err2 != nil:
+			return Changed
+		case newBound == oldBound:
+			return Unchanged
+		}
+		if lower := key == "min" || key == "gt"; (newBound > oldBound) == lower {
+			return Stricter
+		}
+		return Looser
+	case "in":
+		return compareSets(old, new)
+	case groupsTag:
+		// Rules evaluated in more groups reject more values.
+		switch c := compareSets(old, new); c {
+		case Looser:
+			return Stricter
+		case Stricter:
+			return Looser
+		default:
+			return c
+		}
+	}
+	return Changed
+}
+
+// compareSets compares set parameters: a larger set accepts more values.
+func compareSets(old, new param) Compatibility {
+	oldSet, newSet := setOf(old.list()), setOf(new.list())
+	oldInNew, newInOld := subset(oldSet, newSet), subset(newSet, oldSet)
+	switch {
+	case oldInNew && newInOld:
+		return Unchanged
+	case oldInNew:
+		return Looser
+	case newInOld:
+		return Stricter
+	}
+	return Changed
+}
+
+func setOf(elems []string) map[string]bool {
+	res := make(map[string]bool, len(elems))
+	for _, elem := range elems {
+		res[elem] = true
+	}
+	return res
+}
+
+// subset reports whether every element of a is in b.
+func subset(a, b map[string]bool) bool {
+	for elem := range a {
+		if !b[elem] {
+			return false
+		}
+	}
+	return true
+}
+
+// findRule returns the rule of rules named key written for role, "" for
+// rules without a role.
+func findRule(rules []tagRule, key, role string) (tagRule, bool) {
+	for _, tr := range rules {
+		if tr.key == key && tr.role == role {
+			return tr, true
+		}
+	}
+	return tagRule{}, false
+}
+
+// ruleString returns tr as written in a tag.
+func ruleString(tr tagRule, o *options) string {
+	if tr.param.val == "" {
+		return ruleName(tr, o)
+	}
+	return ruleName(tr, o) + string(o.kvSep) + tr.param.val
+}
+
+// ruleName returns the name of tr, prefixed by its role if any, e.g.
+// "role:admin=max".
+func ruleName(tr tagRule, o *options) string {
+	if tr.role == "" {
+		return tr.key
+	}
+	return roleTag + string(o.kvSep) + tr.role + "=" + tr.key
+}
+
+func fieldIndex(p *structPlan, name string) int {
+	for i := range p.fields {
+		if p.fields[i].name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// elemStruct returns the struct type validated field by field for values
+// of type t, looking through pointers, slices, arrays and maps, or nil.
+func elemStruct(t reflect.Type, o *options) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			if isScalar(t, o) {
+				return nil
+			}
+			t = t.Elem()
+		case reflect.Struct:
+			if isScalar(t, o) {
+				return nil
+			}
+			return t
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareRules(t *testing.T) {
+	type AddressV1 struct {
+		City string `validate:"max:40"`
+	}
+	type AddressV2 struct {
+		City string `validate:"max:20"`
+	}
+	type V1 struct {
+		Name    string         `validate:"min:3;max:50"`
+		Role    string         `validate:"in:admin,user"`
+		Nick    string         `validate:"omitempty;min:2"`
+		Age     int            `validate:"min:18"`
+		Tags    map[string]int `validate:"max:10;keys:len:3"`
+		Address *AddressV1
+		Legacy  string  `validate:"required:"`
+		Same    string  `validate:"len:2"`
+		Score   int     `validate:"gt:0;lt:10"`
+		Ratio   float64 `validate:"min:3;max:5"`
+	}
+	type V2 struct {
+		Name    string         `validate:"min:5;max:50"`
+		Role    string         `validate:"in:admin,user,guest"`
+		Nick    string         `validate:"min:2"`
+		Age     int            `validate:"min:18;len:2"`
+		Tags    map[string]int `validate:"max:10;keys:len:4"`
+		Address *AddressV2
+		Same    string  `validate:"len:2"`
+		Score   int     `validate:"gt:1;lt:20"`
+		Ratio   float64 `validate:"min:3.0;max:5.0"`
+		Email   string  `validate:"required:;email:"`
+	}
+	changes, err := CompareRules(V1{}, &V2{})
+	assert.NoError(t, err)
+	assert.Equal(t, []FieldChange{
+		{Path: ".Name", Compatibility: Stricter, Rules: []RuleChange{
+			{Rule: "min", Old: "min:3", New: "min:5", Compatibility: Stricter},
+		}},
+		{Path: ".Role", Compatibility: Looser, Rules: []RuleChange{
+			{Rule: "in", Old: "in:admin,user", New: "in:admin,user,guest", Compatibility: Looser},
+		}},
+		{Path: ".Nick", Compatibility: Stricter, Rules: []RuleChange{
+			{Rule: "omitempty", Old: "omitempty", Compatibility: Stricter},
+		}},
+		{Path: ".Age", Compatibility: Stricter, Rules: []RuleChange{
+			{Rule: "len", New: "len:2", Compatibility: Stricter},
+		}},
+		{Path: ".Tags", Compatibility: Changed, Rules: []RuleChange{
+			{Rule: "keys:len", Old: "len:3", New: "len:4", Compatibility: Changed},
+		}},
+		{Path: ".Address.City", Compatibility: Stricter, Rules: []RuleChange{
+			{Rule: "max", Old: "max:40", New: "max:20", Compatibility: Stricter},
+		}},
+		{Path: ".Legacy", Compatibility: Looser, Rules: []RuleChange{
+			{Rule: "required", Old: "required", Compatibility: Looser},
+		}},
+		{Path: ".Score", Compatibility: Changed, Rules: []RuleChange{
+			{Rule: "gt", Old: "gt:0", New: "gt:1", Compatibility: Stricter},
+			{Rule: "lt", Old: "lt:10", New: "lt:20", Compatibility: Looser},
+		}},
+		{Path: ".Email", Compatibility: Stricter, Rules: []RuleChange{
+			{Rule: "required", New: "required", Compatibility: Stricter},
+			{Rule: "email", New: "email", Compatibility: Stricter},
+		}},
+	}, changes)
+	assert.True(t, changes[0].Breaking())
+	assert.False(t, changes[1].Breaking())
+
+	changes, err = CompareRules(V1{}, V1{})
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	_, err = CompareRules(V1{}, 1)
+	assert.Equal(t, ErrNotStruct, err)
+}
@@ -0,0 +1,165 @@
+// Package config decodes configuration files and validates them with
+// package validate.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	validate "github.com/UNEXPECTEDsemicolon/go-validate"
+	"gopkg.in/yaml.v3"
+)
+
+// DecodeYAMLStream decodes the documents of the YAML stream read from r,
+// separated by "---", into values of type T and validates each of them
+// with v, or with the package default Validator when v is nil.
+//
+// Validation errors are gathered across documents. Their paths start with
+// the index of the document, e.g. [1].Name, and their messages tell the
+// line of the failed value when it is found in the document:
+// [1].Name (line 7): validation failed for "min" tag. Errors preventing
+// decoding or validation are returned as they occur.
+func DecodeYAMLStream[T any](v validate.ValidatorIface, r io.Reader, opts ...validate.Option) ([]T, error) {
+	var docs []T
+	var valErrs validate.ValidationErrors
+	dec := yaml.NewDecoder(r)
+	for i := 0; ; i++ {
+		var node yaml.Node
+		if err := dec.Decode(&node); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		var doc T
+		if err := node.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		docs = append(docs, doc)
+		var err error
+		// The appended document is validated through a pointer, so that
+		// WithNormalize writes to it.
+		if v == nil {
+			err = validate.Validate(&docs[len(docs)-1], opts...)
+		} else {
+			err = v.Validate(&docs[len(docs)-1], opts...)
+		}
+		var docErrs validate.ValidationErrors
+		if err == nil {
+			continue
+		} else if !errors.As(err, &docErrs) || len(docErrs) == 1 && docErrs[0].Rule == "" {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		for _, fe := range docErrs {
+			valErrs = append(valErrs, locate(fe, i, &node, reflect.TypeOf(doc)))
+		}
+	}
+	if len(valErrs) > 0 {
+		return docs, valErrs
+	}
+	return docs, nil
+}
+
+// locate prefixes the path of fe, a failure in document i, with the index
+// of the document, and adds to its message the line of the failed value.
+func locate(fe validate.FieldError, i int, doc *yaml.Node, t reflect.Type) validate.FieldError {
+	path := "[" + strconv.Itoa(i) + "]" + fe.Path
+	msg := path
+	if segs, err := validate.ParseFieldPath(fe.Path); err == nil && len(doc.Content) > 0 {
+		if line := lineOf(doc.Content[0], t, segs); line > 0 {
+			msg += " (line " + strconv.Itoa(line) + ")"
+		}
+	}
+	// Messages set with msg: or translated do not start with the path.
+	text := fe.Err.Error()
+	if rest, ok := strings.CutPrefix(text, fe.Path+": "); ok && fe.Path != "" {
+		text = rest
+	}
+	fe.Err = locatedError{msg: msg + ": " + text, err: fe.Err}
+	fe.Path = path
+	return fe
+}
+
+// locatedError is the error of a failure located in a YAML stream. It
+// wraps the error of the failure, so errors.Is still matches it.
+type locatedError struct {
+	msg string
+	err error
+}
+
+func (e locatedError) Error() string {
+	return e.msg
+}
+
+func (e locatedError) Unwrap() error {
+	return e.err
+}
+
+// lineOf returns the line of the value at segs in node, decoded into a
+// value of type t, or of its innermost ancestor found.
+func lineOf(node *yaml.Node, t reflect.Type, segs []validate.PathSegment) int {
+	line := node.Line
+	for _, seg := range segs {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		for node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		var key string
+		switch {
+		case seg.Field != "" && t.Kind() == reflect.Struct:
+			sf, ok := t.FieldByName(seg.Field)
+			if !ok {
+				return line
+			}
+			key, t = yamlName(sf), sf.Type
+		case seg.Key != "" && node.Kind == yaml.SequenceNode:
+			n, err := strconv.Atoi(seg.Key)
+			if err != nil || n >= len(node.Content) {
+				return line
+			}
+			node, t = node.Content[n], t.Elem()
+			line = node.Line
+			continue
+		case seg.Key != "" && t.Kind() == reflect.Map:
+			key, t = seg.Key, t.Elem()
+			if s, err := strconv.Unquote(key); err == nil {
+				key = s
+			}
+		default:
+			return line
+		}
+		if node.Kind != yaml.MappingNode {
+			return line
+		}
+		found := false
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == key {
+				line = node.Content[j].Line
+				if !seg.MapKey {
+					node = node.Content[j+1]
+				}
+				found = true
+				break
+			}
+		}
+		if !found || seg.MapKey {
+			return line
+		}
+	}
+	return line
+}
+
+// yamlName returns the key of field in YAML, following the rules of
+// gopkg.in/yaml.v3.
+func yamlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	validate "github.com/UNEXPECTEDsemicolon/go-validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type service struct {
+	Name    string            `validate:"min:3"`
+	Port    int               `yaml:"listen_port" validate:"min:1024"`
+	Hosts   []string          `validate:"min:4"`
+	Limits  map[string]int    `validate:"max:10"`
+	Labels  map[string]string `validate:"keys:len:2"`
+	Backend *backend
+}
+
+type backend struct {
+	URL string `yaml:"url" validate:"url"`
+}
+
+const stream = `name: api
+listen_port: 8080
+---
+name: db
+listen_port: 80
+hosts:
+  - db1.internal
+  - db
+limits:
+  cpu: 20
+labels:
+  env: prod
+backend:
+  url: "::"
+---
+name: cache
+listen_port: 6379
+`
+
+func TestDecodeYAMLStream(t *testing.T) {
+	docs, err := DecodeYAMLStream[service](nil, strings.NewReader(stream))
+	assert.Len(t, docs, 3)
+	assert.Equal(t, "cache", docs[2].Name)
+	var valErrs validate.ValidationErrors
+	assert.True(t, errors.As(err, &valErrs))
+	assert.EqualError(t, err, `[1].Name (line 4): validation failed for "min" tag`+
+		`; [1].Port (line 5): validation failed for "min" tag`+
+		`; [1].Hosts[1] (line 8): validation failed for "min" tag`+
+		`; [1].Limits["cpu"] (line 10): validation failed for "max" tag`+
+		`; [1].Labels[key="env"] (line 12): validation failed for "len" tag`+
+		`; [1].Backend.URL (line 14): validation failed for "url" tag`)
+	assert.Equal(t, "[1].Port", valErrs[1].Path)
+	assert.Equal(t, "min", valErrs[1].Rule)
+
+	docs, err = DecodeYAMLStream[service](validate.New(), strings.NewReader("name: web\nlisten_port: 8443\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []service{{Name: "web", Port: 8443}}, docs)
+
+	_, err = DecodeYAMLStream[service](nil, strings.NewReader("name: a\n---\nname: [\n"))
+	assert.ErrorContains(t, err, "document 1: ")
+
+	type bad struct {
+		A int `validate:"min:x"`
+	}
+	_, err = DecodeYAMLStream[bad](nil, strings.NewReader("a: 1\n"))
+	assert.ErrorIs(t, err, validate.ErrInvalidValidatorSyntax)
+}
+
+func TestDecodeYAMLStreamMessages(t *testing.T) {
+	type user struct {
+		Name string `validate:"min:3;msg:name too short"`
+		Age  int    `validate:"min:18"`
+	}
+	_, err := DecodeYAMLStream[user](nil, strings.NewReader("name: al\nage: 7\n"))
+	assert.EqualError(t, err, `[0].Name (line 1): name too short`+
+		`; [0].Age (line 2): validation failed for "min" tag`)
+	assert.ErrorIs(t, err, validate.ErrRuleFailed)
+
+	_, err = DecodeYAMLStream[user](nil, strings.NewReader("name: al\nage: 7\n"), validate.WithJSONPointerPaths())
+	assert.EqualError(t, err, `[0]/Name: name too short`+
+		`; [0]/Age: validation failed for "min" tag`)
+}
+
+func TestDecodeYAMLStreamNormalize(t *testing.T) {
+	type contact struct {
+		Email string `validate:"trim|lower|email"`
+	}
+	docs, err := DecodeYAMLStream[contact](nil, strings.NewReader("email: ' Ann@Example.com '\n---\nemail: bob@example.com\n"), validate.WithNormalize())
+	assert.NoError(t, err)
+	assert.Equal(t, []contact{{"ann@example.com"}, {"bob@example.com"}}, docs)
+}
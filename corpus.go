@@ -0,0 +1,180 @@
+package validate
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CorpusEntry is a failing input recorded by a Recorder, one JSON object
+// per line of the corpus.
+type CorpusEntry struct {
+	// Type is the name of the validated type, e.g. "api.Order".
+	Type string `json:"type"`
+	// Input is the JSON encoding of the input, without redacted values.
+	Input json.RawMessage `json:"input"`
+	// Outcomes holds the outcome of the fields having rules, keyed by path,
+	// as written by Outcome.String.
+	Outcomes map[string]string `json:"outcomes"`
+}
+
+// Recorder writes the inputs failing validation to a corpus, to be
+// replayed with Replay against new versions of the rules. It is safe for
+// concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	w      io.Writer
+	redact [][]string
+	err    error
+}
+
+// NewRecorder returns a Recorder writing to w. Values at the redact paths
+// are left out of the recorded inputs. Paths are dotted JSON object keys,
+// e.g. "card.number", and apply through arrays.
+func NewRecorder(w io.Writer, redact ...string) *Recorder {
+	r := &Recorder{w: w}
+	for _, path := range redact {
+		r.redact = append(r.redact, strings.Split(path, "."))
+	}
+	return r
+}
+
+// WithRecorder makes inputs failing validation be recorded by r. Validation
+// results are not affected by recording errors, see Recorder.Err.
+func WithRecorder(r *Recorder) Option {
+	return func(o *options) {
+		o.recorder = r
+	}
+}
+
+// Err returns the first error met while recording.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// record writes the entry of input v having the given outcomes.
+func (r *Recorder) record(v reflect.Value, outcomes map[string]Outcome) {
+	entry, err := r.entry(v, outcomes)
+	var line []byte
+	if err == nil {
+		line, err = json.Marshal(entry)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if err == nil {
+		_, err = r.w.Write(append(line, '\n'))
+	}
+	r.err = err
+}
+
+func (r *Recorder) entry(v reflect.Value, outcomes map[string]Outcome) (*CorpusEntry, error) {
+	entry := &CorpusEntry{Type: v.Type().String(), Outcomes: make(map[string]string, len(outcomes))}
+	for path, o := range outcomes {
+		entry.Outcomes[path] = o.String()
+	}
+	input, err := json.Marshal(v.Interface())
+	if err != nil || len(r.redact) == 0 {
+		entry.Input = input
+		return entry, err
+	}
+	var tree any
+	if err := json.Unmarshal(input, &tree); err != nil {
+		return nil, err
+	}
+	for _, path := range r.redact {
+		redact(tree, path)
+	}
+	entry.Input, err = json.Marshal(tree)
+	return entry, err
+}
+
+// redact removes the value at path from the decoded JSON tree.
+func redact(tree any, path []string) {
+	switch t := tree.(type) {
+	case []any:
+		for _, elem := range t {
+			redact(elem, path)
+		}
+	case map[string]any:
+		if len(path) == 1 {
+			delete(t, path[0])
+		} else if sub, ok := t[path[0]]; ok {
+			redact(sub, path[1:])
+		}
+	}
+}
+
+// ReplayResult is the outcome of replaying a corpus entry.
+type ReplayResult struct {
+	Entry CorpusEntry
+	// Outcomes holds the outcomes of the fields with the current rules.
+	Outcomes map[string]string
+	// Changed lists, sorted, the paths whose outcome differs from the
+	// recorded one.
+	Changed []string
+}
+
+// Replay replays a corpus with the package default Validator.
+func Replay(r io.Reader, types []any, opts ...Option) ([]ReplayResult, error) {
+	return defaultValidator.Replay(r, types, opts...)
+}
+
+// Replay decodes the inputs of the corpus read from r and validates them
+// again, reporting the fields whose outcomes changed. types holds a value
+// of each type found in the corpus. Redacted values decode as zero values,
+// so the outcomes of their fields may change regardless of the rules.
+func (v *Validator) Replay(r io.Reader, types []any, opts ...Option) ([]ReplayResult, error) {
+	byName := make(map[string]reflect.Type, len(types))
+	for _, sample := range types {
+		t := indirectType(reflect.TypeOf(sample))
+		byName[t.String()] = t
+	}
+	var res []ReplayResult
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<26)
+	for line := 1; scanner.Scan(); line++ {
+		var entry CorpusEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return res, fmt.Errorf("corpus line %d: %v", line, err)
+		}
+		t, ok := byName[entry.Type]
+		if !ok {
+			return res, fmt.Errorf("corpus line %d: unknown type %s", line, entry.Type)
+		}
+		input := reflect.New(t)
+		if err := json.Unmarshal(entry.Input, input.Interface()); err != nil {
+			return res, fmt.Errorf("corpus line %d: %v", line, err)
+		}
+		result := v.Check(input.Interface(), opts...)
+		if result.err != nil {
+			return res, fmt.Errorf("corpus line %d: %w", line, result.err)
+		}
+		replay := ReplayResult{Entry: entry, Outcomes: make(map[string]string, len(result.outcomes))}
+		for path, o := range result.outcomes {
+			replay.Outcomes[path] = o.String()
+		}
+		for path, o := range replay.Outcomes {
+			if old, ok := entry.Outcomes[path]; ok && old != o || !ok && o != NotEvaluated.String() {
+				replay.Changed = append(replay.Changed, path)
+			}
+		}
+		for path, old := range entry.Outcomes {
+			if _, ok := replay.Outcomes[path]; !ok && old != NotEvaluated.String() {
+				replay.Changed = append(replay.Changed, path)
+			}
+		}
+		sort.Strings(replay.Changed)
+		res = append(res, replay)
+	}
+	return res, scanner.Err()
+}
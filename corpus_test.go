@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type corpusCard struct {
+	Number string `json:"number" validate:"len:16"`
+	Holder string `json:"holder"`
+}
+
+type corpusOrder struct {
+	Coupon string       `json:"coupon" validate:"max:8"`
+	Qty    int          `json:"qty" validate:"min:1"`
+	Cards  []corpusCard `json:"cards"`
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRecorderAndReplay(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf, "cards.number")
+	v := New(WithRecorder(rec))
+
+	assert.NoError(t, v.Validate(corpusOrder{Coupon: "SAVE", Qty: 1}))
+	assert.Error(t, v.Validate(&corpusOrder{
+		Coupon: "SAVE10NOW",
+		Qty:    2,
+		Cards:  []corpusCard{{Number: "4111111111111111", Holder: "Ann"}},
+	}))
+	assert.NoError(t, rec.Err())
+	assert.Equal(t, `{"type":"validate.corpusOrder",`+
+		`"input":{"cards":[{"holder":"Ann"}],"coupon":"SAVE10NOW","qty":2},`+
+		`"outcomes":{".Cards[0].Number":"passed",".Coupon":"failed",".Qty":"passed"}}`+"\n", buf.String())
+
+	corpus := buf.String()
+	res, err := Replay(strings.NewReader(corpus), []any{corpusOrder{}})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, []string{".Cards[0].Number"}, res[0].Changed)
+	assert.Equal(t, "failed", res[0].Outcomes[".Coupon"])
+
+	_, err = Replay(strings.NewReader(corpus), nil)
+	assert.EqualError(t, err, "corpus line 1: unknown type validate.corpusOrder")
+	_, err = Replay(strings.NewReader("{"), nil)
+	assert.ErrorContains(t, err, "corpus line 1: ")
+
+	rec = NewRecorder(failingWriter{})
+	assert.Error(t, Validate(corpusOrder{}, WithRecorder(rec)))
+	assert.EqualError(t, rec.Err(), "disk full")
+}
@@ -0,0 +1,286 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// crossFieldRule returns a rule comparing a field to the field referenced
+// by its parameter, e.g. "gtfield:Min" or "eqfield:Account.Password".
+// accept tells whether the rule holds given the result of the comparison,
+// -1, 0 or 1 as the field is less than, equal to or greater than the
+// referenced field. Besides the types supported by expr, times are
+// compared, so that "gtfield:StartsAt" requires EndsAt to be after
+// StartsAt. Nil pointers are only equal to each other, and leave ordering
+// rules to required.
+func crossFieldRule(name string, ordered bool, accept func(cmp int) bool) rule {
+	return rule{
+		assertField: func(fl fieldLevel, p param) (bool, error) {
+			other, err := p.compiled.(*fieldRef).value(fl)
+			if err != nil {
+				return false, err
+			}
+			l, r := indirect(fl.field), indirect(other)
+			if !l.IsValid() || !r.IsValid() {
+				if ordered {
+					return true, nil
+				}
+				return accept(boolCmp(l.IsValid() == r.IsValid())), nil
+			}
+			lv, err := fieldValue(l)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			rv, err := fieldValue(r)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			op := "=="
+			if ordered {
+				op = "<"
+			}
+			cmp, err := compareFields(lv, rv, op)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			return accept(cmp), nil
+		},
+		compile: func(p param, owner reflect.Type) (any, error) {
+			if owner == nil {
+				return nil, fmt.Errorf("%w: %s is only supported on struct fields", ErrInvalidValidatorSyntax, name)
+			}
+			ref, err := newFieldRef(owner, p.val)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", ErrInvalidValidatorSyntax, name, err)
+			}
+			if ref.typ != nil {
+				if _, err := fieldValue(reflect.Zero(indirectType(ref.typ))); err != nil {
+					return nil, fmt.Errorf("%w: %s: field %q: %v", ErrInvalidValidatorSyntax, name, p.val, err)
+				}
+			}
+			return ref, nil
+		},
+	}
+}
+
+// fieldValue converts a field compared by a cross-field rule to an
+// expression value or a time.Time.
+func fieldValue(v reflect.Value) (any, error) {
+	if v.Type() == timeType && v.CanInterface() {
+		return v.Interface().(time.Time), nil
+	}
+	return exprValue(v)
+}
+
+// compareFields is compareValues also comparing times.
+func compareFields(l, r any, op string) (int, error) {
+	if lt, ok := l.(time.Time); ok {
+		if rt, ok := r.(time.Time); ok {
+			return lt.Compare(rt), nil
+		}
+		return 0, fmt.Errorf("cannot compare %T %s %T", l, op, r)
+	}
+	return compareValues(l, r, op)
+}
+
+// boolCmp returns 0 for equal values and 1 otherwise.
+func boolCmp(equal bool) int {
+	if equal {
+		return 0
+	}
+	return 1
+}
+
+// condition is the compiled parameter of conditional rules like
+// "required_if:Type,company,partner": a field reference and the values
+// it is compared to.
+type condition struct {
+	ref    *fieldRef
+	values []string
+}
+
+func compileCondition(name string) func(p param, owner reflect.Type) (any, error) {
+	return func(p param, owner reflect.Type) (any, error) {
+		if owner == nil {
+			return nil, fmt.Errorf("%w: %s is only supported on struct fields", ErrInvalidValidatorSyntax, name)
+		}
+		elems := p.list()
+		if len(elems) < 2 {
+			return nil, fmt.Errorf("%w: %s: expected a field and values in %q", ErrInvalidValidatorSyntax, name, p.val)
+		}
+		ref, err := newFieldRef(owner, elems[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidValidatorSyntax, name, err)
+		}
+		if ref.typ != nil {
+			if _, err := exprValue(reflect.Zero(indirectType(ref.typ))); err != nil {
+				return nil, fmt.Errorf("%w: %s: field %q: %v", ErrInvalidValidatorSyntax, name, elems[0], err)
+			}
+		}
+		return &condition{ref: ref, values: elems[1:]}, nil
+	}
+}
+
+// holds reports whether the referenced field equals one of the values.
+// A nil referenced field equals none.
+func (c *condition) holds(fl fieldLevel) (bool, error) {
+	v, err := c.ref.value(fl)
+	if err != nil {
+		return false, err
+	}
+	if v = indirect(v); !v.IsValid() {
+		return false, nil
+	}
+	ev, err := exprValue(v)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range c.values {
+		if equalsParam(ev, s) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// equalsParam reports whether expression value v equals parameter s.
+func equalsParam(v any, s string) bool {
+	switch v := v.(type) {
+	case string:
+		return v == s
+	case int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return err == nil && v == n
+	case float64:
+		f, err := strconv.ParseFloat(s, 64)
+		return err == nil && v == f
+	case bool:
+		b, err := strconv.ParseBool(s)
+		return err == nil && v == b
+	}
+	return false
+}
+
+// present reports whether field v is set: not nil for pointers and
+// interfaces, not zero otherwise.
+func present(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return !v.IsNil()
+	}
+	return !v.IsZero()
+}
+
+// requiredIfRule requires a field when the referenced field equals one of
+// the values, or with unless, when it equals none of them.
+func requiredIfRule(name string, unless bool) rule {
+	return rule{
+		assertField: func(fl fieldLevel, p param) (bool, error) {
+			holds, err := p.compiled.(*condition).holds(fl)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			if holds == unless {
+				return true, nil
+			}
+			return present(fl.field), nil
+		},
+		compile: compileCondition(name),
+	}
+}
+
+func compileFieldRefs(name string) func(p param, owner reflect.Type) (any, error) {
+	return func(p param, owner reflect.Type) (any, error) {
+		if owner == nil {
+			return nil, fmt.Errorf("%w: %s is only supported on struct fields", ErrInvalidValidatorSyntax, name)
+		}
+		var refs []*fieldRef
+		for _, path := range p.list() {
+			ref, err := newFieldRef(owner, path)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", ErrInvalidValidatorSyntax, name, err)
+			}
+			refs = append(refs, ref)
+		}
+		return refs, nil
+	}
+}
+
+// requiredWithRule requires a field when any of the referenced fields is
+// present, or with without, when any of them is absent. Tagging Email with
+// "required_without:Phone" and Phone with "required_without:Email"
+// requires at least one of them.
+func requiredWithRule(name string, without bool) rule {
+	return rule{
+		assertField: func(fl fieldLevel, p param) (bool, error) {
+			ref, err := firstRef(fl, p.compiled.([]*fieldRef), !without)
+			if err != nil {
+				return false, fmt.Errorf("%s: %w", name, err)
+			}
+			if ref == nil {
+				return true, nil
+			}
+			return present(fl.field), nil
+		},
+		compile: compileFieldRefs(name),
+	}
+}
+
+// excludedWithRule requires a field to be absent when any of the
+// referenced fields is present, e.g. "excluded_with:GiftCardCode" on
+// CouponCode. Failures name the present field.
+var excludedWithRule = rule{
+	assertField: func(fl fieldLevel, p param) (bool, error) {
+		ref, err := firstRef(fl, p.compiled.([]*fieldRef), true)
+		if err != nil {
+			return false, fmt.Errorf("excluded_with: %w", err)
+		}
+		if ref == nil {
+			return true, nil
+		}
+		return !present(fl.field), nil
+	},
+	explain: func(fl fieldLevel, p param) string {
+		ref, _ := firstRef(fl, p.compiled.([]*fieldRef), true)
+		return fmt.Sprintf("must be empty when %s is set", ref.path)
+	},
+	compile: compileFieldRefs("excluded_with"),
+}
+
+// excludedIfRule requires a field to be absent when the referenced field
+// equals one of the values, e.g. "excluded_if:Type,person" on CompanyName.
+var excludedIfRule = rule{
+	assertField: func(fl fieldLevel, p param) (bool, error) {
+		holds, err := p.compiled.(*condition).holds(fl)
+		if err != nil {
+			return false, fmt.Errorf("excluded_if: %w", err)
+		}
+		if !holds {
+			return true, nil
+		}
+		return !present(fl.field), nil
+	},
+	explain: func(fl fieldLevel, p param) string {
+		c := p.compiled.(*condition)
+		v, _ := c.ref.value(fl)
+		return fmt.Sprintf("must be empty when %s is %v", c.ref.path, indirect(v))
+	},
+	compile: compileCondition("excluded_if"),
+}
+
+// firstRef returns the first of refs whose field presence is as given, or
+// nil if there is none.
+func firstRef(fl fieldLevel, refs []*fieldRef, isPresent bool) (*fieldRef, error) {
+	for _, ref := range refs {
+		v, err := ref.value(fl)
+		if err != nil {
+			return nil, err
+		}
+		if present(v) == isPresent {
+			return ref, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrossFieldRules(t *testing.T) {
+	type Account struct {
+		Password string
+	}
+	type S struct {
+		Account
+		Confirm string `validate:"eqfield:Account.Password"`
+		Old     string `validate:"nefield:Password"`
+		Min     int
+		Max     int     `validate:"gtfield:Min"`
+		Low     float64 `validate:"ltfield:Max"`
+		Limit   *int    `validate:"gtfield:Min"`
+		Backup  *string `validate:"eqfield:Nick"`
+		Nick    *string
+	}
+	one, nick := 1, "nick"
+	ok := S{
+		Account: Account{Password: "secret"},
+		Confirm: "secret",
+		Old:     "hunter2",
+		Min:     1,
+		Max:     10,
+		Low:     9.5,
+	}
+	assert.NoError(t, Validate(ok))
+
+	bad := ok
+	bad.Confirm = "secrets"
+	bad.Old = "secret"
+	bad.Max = 1
+	bad.Low = 1
+	bad.Limit = &one
+	bad.Nick = &nick
+	assert.EqualError(t, Validate(bad), `.Confirm: validation failed for "eqfield" tag`+
+		`; .Old: validation failed for "nefield" tag`+
+		`; .Max: validation failed for "gtfield" tag`+
+		`; .Low: validation failed for "ltfield" tag`+
+		`; .Limit: validation failed for "gtfield" tag`+
+		`; .Backup: validation failed for "eqfield" tag`)
+
+	type P struct {
+		A int `validate:"gtfield=B"`
+		B int
+	}
+	assert.EqualError(t, Validate(P{}, WithPlaygroundSyntax()), `.A: validation failed for "gtfield" tag`)
+
+	type Bad struct {
+		A int `validate:"gtfield:B"`
+		B bool
+	}
+	assert.ErrorContains(t, Validate(Bad{}), "gtfield: cannot compare int64 < bool")
+	type Missing struct {
+		A string `validate:"eqfield:B.C"`
+		B struct{}
+	}
+	assert.ErrorContains(t, Validate(Missing{}), `eqfield: field reference "B.C": unknown field "C"`)
+	assert.ErrorContains(t, Var(1, "eqfield:B"), "eqfield is only supported on struct fields")
+}
+
+func TestRequiredIf(t *testing.T) {
+	type S struct {
+		Type        string
+		Verified    *bool
+		CompanyName string  `validate:"required_if:Type,company,partner"`
+		VAT         *string `validate:"required_if:Verified,true"`
+		FirstName   string  `validate:"required_unless:Type,company"`
+	}
+	yes, vat := true, ""
+	assert.NoError(t, Validate(S{Type: "company", CompanyName: "ACME"}))
+	assert.NoError(t, Validate(S{Type: "person", FirstName: "Ann"}))
+	assert.NoError(t, Validate(S{Type: "person", FirstName: "Ann", Verified: &yes, VAT: &vat}))
+	assert.EqualError(t, Validate(S{Type: "partner", Verified: &yes}),
+		`.CompanyName: validation failed for "required_if" tag`+
+			`; .VAT: validation failed for "required_if" tag`+
+			`; .FirstName: validation failed for "required_unless" tag`)
+
+	type Bad struct {
+		A string `validate:"required_if:B"`
+		B string
+	}
+	assert.ErrorContains(t, Validate(Bad{}), `required_if: expected a field and values in "B"`)
+}
+
+func TestRequiredWith(t *testing.T) {
+	type Contact struct {
+		Email  string  `validate:"required_without:Phone"`
+		Phone  *string `validate:"required_without:Email"`
+		Street string
+		City   string `validate:"required_with:Street,Zip"`
+		Zip    int
+	}
+	phone := "+15550100"
+	assert.NoError(t, Validate(Contact{Email: "a@example.com"}))
+	assert.NoError(t, Validate(Contact{Phone: &phone, Street: "Main St", City: "Springfield"}))
+	assert.EqualError(t, Validate(Contact{Zip: 12345}),
+		`.Email: validation failed for "required_without" tag`+
+			`; .Phone: validation failed for "required_without" tag`+
+			`; .City: validation failed for "required_with" tag`)
+	assert.ErrorContains(t, Validate(struct {
+		A string `validate:"required_with:B"`
+	}{}), `required_with: field reference "B"`)
+}
+
+func TestExcluded(t *testing.T) {
+	type Order struct {
+		GiftCardCode string
+		Voucher      *string
+		CouponCode   string `validate:"excluded_with:Voucher,GiftCardCode"`
+		Type         string
+		CompanyName  string `validate:"excluded_if:Type,person"`
+	}
+	voucher := "V1"
+	assert.NoError(t, Validate(Order{CouponCode: "SAVE10", Type: "company", CompanyName: "ACME"}))
+	assert.NoError(t, Validate(Order{GiftCardCode: "GC", Type: "person"}))
+	assert.EqualError(t, Validate(Order{GiftCardCode: "GC", CouponCode: "SAVE10", Type: "person", CompanyName: "ACME"}),
+		`.CouponCode: validation failed for "excluded_with" tag: must be empty when GiftCardCode is set`+
+			`; .CompanyName: validation failed for "excluded_if" tag: must be empty when Type is person`)
+	assert.EqualError(t, Validate(Order{Voucher: &voucher, CouponCode: "SAVE10"}),
+		`.CouponCode: validation failed for "excluded_with" tag: must be empty when Voucher is set`)
+}
+
+func TestCrossFieldTimes(t *testing.T) {
+	type Booking struct {
+		StartsAt time.Time
+		EndsAt   time.Time  `validate:"gtfield:StartsAt"`
+		CheckIn  *time.Time `validate:"ltfield:EndsAt"`
+		Created  time.Time  `validate:"eqfield:StartsAt"`
+	}
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	checkIn := start.Add(time.Hour)
+	ok := Booking{StartsAt: start, EndsAt: start.Add(2 * time.Hour), CheckIn: &checkIn, Created: start.In(time.FixedZone("CET", 3600))}
+	assert.NoError(t, Validate(ok))
+	assert.NoError(t, Validate(Booking{StartsAt: start, EndsAt: start.Add(time.Hour), Created: start}))
+
+	late := start.Add(3 * time.Hour)
+	bad := ok
+	bad.EndsAt = start
+	bad.CheckIn = &late
+	bad.Created = start.Add(time.Second)
+	assert.EqualError(t, Validate(bad), `.EndsAt: validation failed for "gtfield" tag`+
+		`; .CheckIn: validation failed for "ltfield" tag`+
+		`; .Created: validation failed for "eqfield" tag`)
+
+	type Mixed struct {
+		At  time.Time `validate:"gtfield:Min"`
+		Min int
+	}
+	assert.ErrorContains(t, Validate(Mixed{}), "gtfield: cannot compare time.Time < int64")
+}
@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithData sets the value of key in the data of a validation call, which
+// rules read through data references: a rule parameter written $key, as
+// in "in:$plans", is replaced by the value of key, and expr expressions
+// may use $key as an operand. This lets a single struct be validated
+// differently per call, e.g. with the plans allowed for the role of the
+// current user:
+//
+//	validate.Validate(req, validate.WithData("plans", allowedPlans(user)))
+//
+// Strings are used as is, string slices are joined by the list separator
+// and other values are formatted with fmt. Parameters referencing keys
+// without a value are used as written.
+func WithData(key string, value any) Option {
+	return func(o *options) {
+		res := make(map[string]any, len(o.data)+1)
+		for k, v := range o.data {
+			res[k] = v
+		}
+		res[key] = value
+		o.data = res
+	}
+}
+
+// dataValue returns the value of key set with WithData for the call
+// evaluating the rule, as read by expr expressions.
+func (fl fieldLevel) dataValue(key string) (any, bool) {
+	v, ok := fl.data[key]
+	return v, ok
+}
+
+// dataRef returns the key referenced by a $key parameter.
+func dataRef(val string) (string, bool) {
+	key, ok := strings.CutPrefix(val, "$")
+	if !ok || key == "" {
+		return "", false
+	}
+	for i := 0; i < len(key); i++ {
+		if !isIdentByte(key[i], i > 0) {
+			return "", false
+		}
+	}
+	return key, true
+}
+
+// resolve replaces a $key parameter of rule by its value in the call
+// data. The parameters of compiled rules are compiled at plan time, but
+// for data references which are compiled once resolved, against the type
+// of parent when valid.
+func (p param) resolve(rule rule, parent reflect.Value) (param, error) {
+	key, ok := dataRef(p.val)
+	if !ok {
+		return p, nil
+	}
+	if v, ok := p.opts.data[key]; ok {
+		p.val = dataString(v, p.listSep)
+	}
+	if rule.compile == nil {
+		return p.parsed(), nil
+	}
+	var owner reflect.Type
+	if parent.IsValid() {
+		owner = parent.Type()
+	}
+	var err error
+	p.compiled, err = rule.compile(p, owner)
+	return p, err
+}
+
+// dataString formats a data value as a parameter.
+func dataString(v any, listSep string) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, listSep)
+	}
+	return fmt.Sprint(v)
+}
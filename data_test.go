@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithData(t *testing.T) {
+	type Subscription struct {
+		Plan  string `validate:"in:$plans"`
+		Seats int    `validate:"max:$seats"`
+		Price string `validate:"eq:$USD"`
+		Admin bool   `validate:"expr:!Admin || $role == 'admin'"`
+	}
+	sub := Subscription{Plan: "pro", Seats: 10, Price: "$USD"}
+	for _, tc := range []struct {
+		name  string
+		sub   Subscription
+		opts  []Option
+		paths []string
+	}{
+		{"allowed", sub, []Option{WithData("plans", []string{"free", "pro"}), WithData("seats", 10)}, nil},
+		{"not allowed", sub, []Option{WithData("plans", "free"), WithData("seats", 5)}, []string{".Plan", ".Seats"}},
+		{"unset", Subscription{Plan: "$plans", Seats: 10, Price: "$USD"}, []Option{WithData("seats", 10)}, nil},
+		{"admin", Subscription{Plan: "pro", Admin: true, Price: "$USD"}, []Option{WithData("plans", "pro"), WithData("seats", 0), WithData("role", "admin")}, nil},
+		{"not admin", Subscription{Plan: "pro", Admin: true, Price: "$USD"}, []Option{WithData("plans", "pro"), WithData("seats", 0), WithData("role", "user")}, []string{".Admin"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.sub, tc.opts...)
+			if tc.paths == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var paths []string
+			for _, fe := range err.(ValidationErrors) {
+				paths = append(paths, fe.Path)
+			}
+			assert.Equal(t, tc.paths, paths)
+		})
+	}
+
+	err := Validate(sub, WithData("plans", []string{"free"}), WithData("seats", 10))
+	assert.EqualError(t, err, `.Plan: validation failed for "in" tag`)
+	assert.Equal(t, "free", err.(ValidationErrors)[0].Param)
+
+	assert.NoError(t, Var("ab", "isregexp:$n", WithData("n", 5)))
+	assert.ErrorIs(t, Var("ab", "isregexp:$n"), ErrInvalidValidatorSyntax)
+	assert.ErrorIs(t, Validate(Subscription{Admin: true}, WithData("plans", "")), ErrInvalidValidatorSyntax)
+}
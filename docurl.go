@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"net/url"
+	"strings"
+)
+
+// WithDocURL makes the failures of rule link to the documentation at the
+// URL given by tmpl, in FieldError.DocURL and in their JSON form. Rule "*"
+// gives the URL of the rules without their own. Placeholders are replaced
+// by the failure, escaped for URL paths: {rule} by the rule name, {code}
+// by its code, {field} by the path of the field without the leading dot
+// and {param} by the parameter of the rule, e.g.
+//
+//	WithDocURL("*", "https://docs.example.com/errors/{code}")
+//
+// The doc tag of a field, e.g. doc:"https://docs.example.com/orders#{rule}",
+// gives the URL of the failures of the values it holds and takes
+// precedence.
+func WithDocURL(rule, tmpl string) Option {
+	return func(o *options) {
+		res := make(map[string]string, len(o.docURLs)+1)
+		for r, t := range o.docURLs {
+			res[r] = t
+		}
+		res[rule] = tmpl
+		o.docURLs = res
+	}
+}
+
+// docURL returns the documentation URL of fe, given the doc tag of the
+// nearest field holding the failed value, if any.
+func (o *options) docURL(fe FieldError, fieldDoc string) string {
+	tmpl := fieldDoc
+	if tmpl == "" {
+		var ok bool
+		if tmpl, ok = o.docURLs[fe.Rule]; !ok {
+			tmpl = o.docURLs["*"]
+		}
+	}
+	if tmpl == "" {
+		return ""
+	}
+	return strings.NewReplacer(
+		"{rule}", url.PathEscape(fe.Rule),
+		"{code}", url.PathEscape(fe.Code),
+		"{field}", url.PathEscape(fieldName(fe.Path)),
+		"{param}", url.PathEscape(fe.Param),
+	).Replace(tmpl)
+}
@@ -0,0 +1,53 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocURLs(t *testing.T) {
+	type Address struct {
+		City string `validate:"required"`
+		Zip  string `validate:"len:5" doc:"https://docs.example.com/zip"`
+	}
+	type Order struct {
+		SKU      string   `validate:"min:3"`
+		Items    []string `validate:"in:a b"`
+		Address  Address  `doc:"https://docs.example.com/address#{field}"`
+		Quantity int      `validate:"max:10"`
+	}
+	v := New(
+		WithDocURL("*", "https://docs.example.com/errors/{code}"),
+		WithDocURL("in", "https://docs.example.com/rules/{rule}?set={param}"),
+	)
+	errs := v.Validate(Order{SKU: "x", Items: []string{"c"}, Address: Address{Zip: "1"}, Quantity: 11}).(ValidationErrors)
+	var urls []string
+	for _, fe := range errs {
+		urls = append(urls, fe.DocURL)
+	}
+	assert.Equal(t, []string{
+		"https://docs.example.com/errors/VAL_MIN",
+		"https://docs.example.com/rules/in?set=a%20b",
+		"https://docs.example.com/address#Address.City",
+		"https://docs.example.com/zip",
+		"https://docs.example.com/errors/VAL_MAX",
+	}, urls)
+
+	b, err := json.Marshal(errs[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"doc_url":"https://docs.example.com/errors/VAL_MIN"`)
+
+	errs = Validate(Order{SKU: "x"}).(ValidationErrors)
+	assert.Empty(t, errs[0].DocURL)
+	b, err = json.Marshal(errs[0])
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "doc_url")
+
+	type Item struct {
+		SKU string
+	}
+	err = v.ValidateAll([]Item{{"a"}, {"a"}}, []BatchConstraint{Unique("SKU")})
+	assert.Equal(t, "https://docs.example.com/errors/VAL_UNIQUE", err.(ValidationErrors)[0].DocURL)
+}
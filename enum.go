@@ -0,0 +1,221 @@
+package validate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	goconstant "go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// EnumDrift is an in: rule of a struct field whose list misses constants
+// declared for the type of the field, see CheckEnums.
+type EnumDrift struct {
+	// Pos locates the field in its source file.
+	Pos token.Position
+	// Field names the field from its struct type, e.g. Order.Status.
+	Field string
+	// Rule is the in: rule as written in the tag.
+	Rule string
+	// Missing lists the constants missing from the list, in declaration
+	// order, e.g. StatusShipped.
+	Missing []string
+}
+
+func (d EnumDrift) String() string {
+	return fmt.Sprintf("%s: %s: %s misses %s", d.Pos, d.Field, d.Rule, strings.Join(d.Missing, ", "))
+}
+
+// CheckEnums checks the in: rules of the package in dir against the
+// constants of the types of their fields using the package default
+// Validator.
+func CheckEnums(dir string, opts ...Option) ([]EnumDrift, error) {
+	return defaultValidator.CheckEnums(dir, opts...)
+}
+
+// CheckEnums parses and type-checks the Go package in dir, without its
+// tests, and reports the in: rules of its struct fields missing constants
+// of the named type of the field, declared in the package of the type.
+// This catches constants added without updating the tags. Rules applying
+// to the elements of slices, arrays and maps, and to pointed values, are
+// checked against the type of the elements. Lists given as data
+// references, see WithData, are skipped. opts set the tag keys and syntax,
+// see WithTagKey. Running it from a test makes drift fail the build:
+//
+//	drifts, err := validate.CheckEnums(".")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	for _, d := range drifts {
+//		t.Error(d)
+//	}
+func (v *Validator) CheckEnums(dir string, opts ...Option) ([]EnumDrift, error) {
+	o := v.opts.with(opts)
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	files := make([]*ast.File, len(bp.GoFiles))
+	for i, name := range bp.GoFiles {
+		if files[i], err = parser.ParseFile(fset, filepath.Join(dir, name), nil, 0); err != nil {
+			return nil, err
+		}
+	}
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check(bp.ImportPath, fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+	c := enumCheck{fset: fset, opts: &o, consts: make(map[*types.Named][]*types.Const)}
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		if tn, ok := scope.Lookup(name).(*types.TypeName); ok && !tn.IsAlias() {
+			if st, ok := tn.Type().Underlying().(*types.Struct); ok {
+				c.structFields(name, st)
+			}
+		}
+	}
+	sort.SliceStable(c.drifts, func(i, j int) bool {
+		a, b := c.drifts[i].Pos, c.drifts[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return c.drifts, nil
+}
+
+// enumCheck holds the state of a CheckEnums call.
+type enumCheck struct {
+	fset   *token.FileSet
+	opts   *options
+	consts map[*types.Named][]*types.Const
+	drifts []EnumDrift
+}
+
+// structFields checks the fields of st, named name, and of the unnamed
+// struct types of its fields.
+func (c *enumCheck) structFields(name string, st *types.Struct) {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		path := name + "." + field.Name()
+		for _, key := range strings.Fields(c.opts.tagKeys) {
+			if tag, ok := reflect.StructTag(st.Tag(i)).Lookup(key); ok {
+				c.tag(path, field, tag)
+			}
+		}
+		if inner, ok := elemType(field.Type()).(*types.Struct); ok {
+			c.structFields(path, inner)
+		}
+	}
+}
+
+// tag checks the in: rules of tag, the tag of field.
+func (c *enumCheck) tag(path string, field *types.Var, tag string) {
+	tagRules, err := parseTag(tag, c.opts)
+	if err != nil {
+		// Invalid tags are reported by validation.
+		return
+	}
+	for _, tr := range tagRules {
+		// Rules wrapped by roles, warn and values: apply to the field
+		// like others, while keys: rules apply to map keys.
+		switch tr.key {
+		case keysTag:
+			continue
+		case roleTag:
+			tr, err = lexRoleRule(tag, tr, c.opts)
+		case warnTag, valuesTag:
+			start := tr.pos + len(tr.key) + utf8.RuneLen(c.opts.kvSep)
+			tr, err = lexRule(tag, start, start+len(tr.param.val), c.opts)
+		}
+		if _, ref := dataRef(tr.param.val); err != nil || tr.key != "in" || ref {
+			continue
+		}
+		named, ok := elemType(field.Type()).(*types.Named)
+		if !ok {
+			continue
+		}
+		var missing []string
+		for _, k := range c.constsOf(named) {
+			if !inList(k.Val(), tr.param) {
+				missing = append(missing, k.Name())
+			}
+		}
+		if len(missing) > 0 {
+			c.drifts = append(c.drifts, EnumDrift{
+				Pos:     c.fset.Position(field.Pos()),
+				Field:   path,
+				Rule:    ruleString(tr, c.opts),
+				Missing: missing,
+			})
+		}
+	}
+}
+
+// constsOf returns the string and integer constants of type t declared in
+// the package of t, in declaration order.
+func (c *enumCheck) constsOf(t *types.Named) []*types.Const {
+	if res, ok := c.consts[t]; ok {
+		return res
+	}
+	var res []*types.Const
+	if pkg := t.Obj().Pkg(); pkg != nil {
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			k, ok := scope.Lookup(name).(*types.Const)
+			if ok && types.Identical(k.Type(), t) && (k.Val().Kind() == goconstant.String || k.Val().Kind() == goconstant.Int) {
+				res = append(res, k)
+			}
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Pos() < res[j].Pos() })
+	c.consts[t] = res
+	return res
+}
+
+// elemType returns the type validated by the rules of a field of type t:
+// the type of its elements or pointed values.
+func elemType(t types.Type) types.Type {
+	for {
+		switch u := t.(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Array:
+			t = u.Elem()
+		case *types.Map:
+			t = u.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// inList reports whether the list of p holds constant value v.
+func inList(v goconstant.Value, p param) bool {
+	for _, elem := range p.values() {
+		switch v.Kind() {
+		case goconstant.String:
+			if elem.s == goconstant.StringVal(v) {
+				return true
+			}
+		case goconstant.Int:
+			if elem.iErr == nil && goconstant.Compare(goconstant.MakeInt64(elem.i), token.EQL, v) ||
+				elem.uErr == nil && goconstant.Compare(goconstant.MakeUint64(elem.u), token.EQL, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const enumSource = `package orders
+
+import "time"
+
+type Status string
+
+const (
+	StatusNew     Status = "new"
+	StatusPaid    Status = "paid"
+	StatusShipped Status = "shipped"
+)
+
+type Priority int
+
+const (
+	Low Priority = iota + 1
+	High
+	Urgent
+)
+
+const Default = "new"
+
+type Order struct {
+	Status   Status              ` + "`" + `validate:"in:new,paid"` + "`" + `
+	Previous *Status             ` + "`" + `validate:"warn:in:new,paid,shipped"` + "`" + `
+	History  []Status            ` + "`" + `validate:"max:3;in:paid"` + "`" + `
+	ByHost   map[string]Priority ` + "`" + `validate:"keys:min:1;values:in:1,2"` + "`" + `
+	Allowed  Status              ` + "`" + `validate:"in:$allowed"` + "`" + `
+	Name     string              ` + "`" + `validate:"in:a,b"` + "`" + `
+	Items    []struct {
+		Priority Priority ` + "`" + `validate:"role:admin=in:1,2,3"` + "`" + `
+		Urgency  Priority ` + "`" + `validate:"in:2,3"` + "`" + `
+	}
+	Created time.Time
+}
+`
+
+func TestCheckEnums(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "orders.go"), []byte(enumSource), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "orders_test.go"), []byte("package orders\n\nvar _ = undefined\n"), 0o644))
+
+	drifts, err := CheckEnums(dir)
+	assert.NoError(t, err)
+	var got []string
+	for _, d := range drifts {
+		got = append(got, filepath.Base(d.String()))
+	}
+	assert.Equal(t, []string{
+		"orders.go:24:2: Order.Status: in:new,paid misses StatusShipped",
+		"orders.go:26:2: Order.History: in:paid misses StatusNew, StatusShipped",
+		"orders.go:27:2: Order.ByHost: in:1,2 misses Urgent",
+		"orders.go:32:3: Order.Items.Urgency: in:2,3 misses Low",
+	}, got)
+
+	_, err = CheckEnums(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package validate
+
+import "strings"
+
+// defaultEnvValueLen caps the length of values checked by the envvalue
+// rule without parameter. It is the limit of Linux on a single argument or
+// environment string, MAX_ARG_STRLEN, less the terminating NUL.
+const defaultEnvValueLen = 128*1024 - 1
+
+// envName reports whether val is a portable environment variable name:
+// letters, digits and underscores, not starting with a digit.
+func envName(val string) bool {
+	if val == "" || '0' <= val[0] && val[0] <= '9' {
+		return false
+	}
+	for i := 0; i < len(val); i++ {
+		c := val[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+var envNameRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		return envName(val), nil
+	},
+	noParam: true,
+}
+
+// envValueRule requires a value an environment variable can hold: no NUL
+// bytes nor line breaks, and at most the number of bytes given by its
+// parameter, e.g. envvalue:4096, or defaultEnvValueLen.
+var envValueRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		return len(val) <= p.compiled.(int) && !strings.ContainsAny(val, "\x00\n\r"), nil
+	},
+	compile: compileLimit("envvalue", defaultEnvValueLen),
+	noParam: true,
+}
@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvRules(t *testing.T) {
+	for _, tt := range []struct {
+		val, tag string
+		ok       bool
+	}{
+		{"PATH", "envname", true},
+		{"_my_var2", "envname", true},
+		{"2FAST", "envname", false},
+		{"MY-VAR", "envname", false},
+		{"MY VAR", "envname", false},
+		{"VÄR", "envname", false},
+		{"", "envname", false},
+		{"/usr/bin:/bin", "envvalue", true},
+		{"", "envvalue", true},
+		{"a=b c", "envvalue", true},
+		{"line\nbreak", "envvalue", false},
+		{"cr\r", "envvalue", false},
+		{"nul\x00", "envvalue", false},
+		{"12345", "envvalue:5", true},
+		{"123456", "envvalue:5", false},
+		{strings.Repeat("x", defaultEnvValueLen), "envvalue", true},
+		{strings.Repeat("x", defaultEnvValueLen+1), "envvalue", false},
+	} {
+		err := Var(tt.val, tt.tag)
+		if tt.ok {
+			assert.NoError(t, err, "%q %s", tt.val, tt.tag)
+		} else {
+			assert.Error(t, err, "%q %s", tt.val, tt.tag)
+		}
+	}
+	assert.ErrorIs(t, Var("x", "envvalue:-1"), ErrInvalidValidatorSyntax)
+	o := defaultOptions()
+	assert.ErrorIs(t, newFieldTag("envvalue:x", nil, &o).err, ErrInvalidValidatorSyntax, "rejected when building plans")
+
+	type Container struct {
+		Env map[string]string `validate:"keys:envname;values:envvalue:64"`
+	}
+	assert.EqualError(t, Validate(Container{Env: map[string]string{"HOME": "/root", "1X": "a\nb"}}),
+		`.Env[key="1X"]: validation failed for "envname" tag`+
+			`; .Env["1X"]: validation failed for "envvalue" tag`)
+}
@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownEvent is returned for payloads of event types which were not
+// registered.
+var ErrUnknownEvent = errors.New("unknown event type")
+
+// EventRegistry maps event types, e.g. "order.created", to the struct
+// types their JSON payloads decode to, so that event-driven systems
+// validate heterogeneous payloads through a single entry point. It is safe
+// for concurrent use.
+type EventRegistry struct {
+	v      ValidatorIface
+	mu     sync.RWMutex
+	events map[string]eventSchema
+}
+
+// eventSchema is the payload type of an event type and the options its
+// payloads are validated with.
+type eventSchema struct {
+	typ  reflect.Type
+	opts []Option
+}
+
+var defaultEvents = NewEventRegistry(defaultValidator)
+
+// NewEventRegistry returns an empty EventRegistry validating payloads with
+// v.
+func NewEventRegistry(v ValidatorIface) *EventRegistry {
+	return &EventRegistry{v: v, events: make(map[string]eventSchema)}
+}
+
+// RegisterEvent registers an event type with the package default
+// EventRegistry.
+func RegisterEvent(eventType string, sample any, opts ...Option) error {
+	return defaultEvents.Register(eventType, sample, opts...)
+}
+
+// ValidateEvent validates a payload with the package default
+// EventRegistry.
+func ValidateEvent(eventType string, payload []byte) error {
+	return defaultEvents.Validate(eventType, payload)
+}
+
+// Register makes the payloads of eventType decode to the struct type of
+// sample, which may be a pointer, and be validated with opts, e.g.
+// WithGroup("v2"). Registering an event type again replaces it.
+func (r *EventRegistry) Register(eventType string, sample any, opts ...Option) error {
+	t := reflect.TypeOf(sample)
+	if t == nil || indirectType(t).Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[eventType] = eventSchema{typ: indirectType(t), opts: append([]Option(nil), opts...)}
+	return nil
+}
+
+// Types returns the registered event types, sorted.
+func (r *EventRegistry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := make([]string, 0, len(r.events))
+	for eventType := range r.events {
+		res = append(res, eventType)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Decode decodes the JSON payload of an event of type eventType and
+// validates it, returning a pointer to the decoded struct. Payloads
+// failing to decode yield an error wrapping the decoding error, and
+// invalid payloads the decoded value along with the validation errors.
+func (r *EventRegistry) Decode(eventType string, payload []byte) (any, error) {
+	return r.DecodeCtx(context.Background(), eventType, payload)
+}
+
+// DecodeCtx is Decode validating payloads with ctx, see ValidateCtx.
+func (r *EventRegistry) DecodeCtx(ctx context.Context, eventType string, payload []byte) (any, error) {
+	r.mu.RLock()
+	schema, ok := r.events[eventType]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownEvent, eventType)
+	}
+	v := reflect.New(schema.typ).Interface()
+	if err := json.Unmarshal(payload, v); err != nil {
+		return nil, fmt.Errorf("event %q: %w", eventType, err)
+	}
+	return v, r.v.ValidateCtx(ctx, v, schema.opts...)
+}
+
+// Validate decodes and validates the JSON payload of an event of type
+// eventType, see Decode.
+func (r *EventRegistry) Validate(eventType string, payload []byte) error {
+	_, err := r.Decode(eventType, payload)
+	return err
+}
+
+// ValidateCtx is Validate validating payloads with ctx, see ValidateCtx.
+func (r *EventRegistry) ValidateCtx(ctx context.Context, eventType string, payload []byte) error {
+	_, err := r.DecodeCtx(ctx, eventType, payload)
+	return err
+}
@@ -0,0 +1,61 @@
+package validate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventRegistry(t *testing.T) {
+	type OrderCreated struct {
+		ID    string `json:"id" validate:"required"`
+		Total int    `json:"total" validate:"min:1"`
+	}
+	type UserDeleted struct {
+		UserID string `json:"user_id" validate:"required"`
+		Reason string `json:"reason" validate:"groups:audited;required"`
+	}
+	r := NewEventRegistry(New())
+	assert.NoError(t, r.Register("order.created", OrderCreated{}))
+	assert.NoError(t, r.Register("user.deleted", &UserDeleted{}, WithGroup("audited")))
+	assert.ErrorIs(t, r.Register("bad", 1), ErrNotStruct)
+	assert.Equal(t, []string{"order.created", "user.deleted"}, r.Types())
+
+	tests := []struct {
+		name      string
+		eventType string
+		payload   string
+		wantErr   string
+	}{
+		{"valid", "order.created", `{"id":"o1","total":5}`, ""},
+		{"invalid", "order.created", `{"id":"o1"}`, `.Total: validation failed for "min" tag`},
+		{"options", "user.deleted", `{"user_id":"u1"}`, `.Reason: validation failed for "required" tag`},
+		{"unknown", "order.shipped", `{}`, `unknown event type "order.shipped"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.Validate(tt.eventType, []byte(tt.payload))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	v, err := r.Decode("order.created", []byte(`{"id":"o1","total":5}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &OrderCreated{ID: "o1", Total: 5}, v)
+	_, err = r.Decode("order.shipped", nil)
+	assert.ErrorIs(t, err, ErrUnknownEvent)
+	_, err = r.Decode("order.created", []byte(`{`))
+	var syntaxErr *json.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+	_, err = r.Decode("order.created", []byte(`{"id":1}`))
+	var typeErr *json.UnmarshalTypeError
+	assert.ErrorAs(t, err, &typeErr)
+
+	assert.NoError(t, RegisterEvent("test.event", OrderCreated{}))
+	assert.Error(t, ValidateEvent("test.event", []byte(`{}`)))
+}
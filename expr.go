@@ -0,0 +1,556 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// exprProgram is a boolean expression compiled against a struct type, as
+// used by the "expr" rule:
+//
+//	Age int `validate:"expr:Age >= 18 && Country == 'US'"`
+//
+// Operands are field references such as Age or Address.Zip (see fieldRef),
+// integer, float and single-quoted string literals, true, false, len(x)
+// and $key, the value of key in the call data (see WithData).
+// Supported operators are || && ! == != < <= > >= + - * / % and parentheses.
+type exprProgram struct {
+	src  string
+	root exprFunc
+}
+
+// exprFunc evaluates an expression node for the field being validated.
+// Results are int64, float64, string or bool.
+type exprFunc func(fl fieldLevel) (any, error)
+
+func (p *exprProgram) eval(fl fieldLevel) (bool, error) {
+	res, err := p.root(fl)
+	if err != nil {
+		return false, p.errorf("%v", err)
+	}
+	ok, isBool := res.(bool)
+	if !isBool {
+		return false, p.errorf("result is %T, not bool", res)
+	}
+	return ok, nil
+}
+
+func (p *exprProgram) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: expr %q: %s", ErrInvalidValidatorSyntax, p.src, fmt.Sprintf(format, args...))
+}
+
+// compileExpr compiles src against struct type owner, resolving field
+// references to field indices once.
+func compileExpr(src string, owner reflect.Type) (*exprProgram, error) {
+	p := &exprProgram{src: src}
+	toks, err := lexExpr(src)
+	if err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	c := &exprCompiler{toks: toks, owner: owner}
+	if p.root, err = c.or(); err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	if tok := c.peek(); tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %q at offset %d", tok.text, tok.pos)
+	}
+	return p, nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNum
+	tokStr
+	tokIdent
+	tokData
+	tokOp
+)
+
+type exprToken struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+var exprOps = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")"}
+
+func lexExpr(src string) ([]exprToken, error) {
+	var toks []exprToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		j := i + 1
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case isDigit(c):
+			for j < len(src) && (isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			toks = append(toks, exprToken{tokNum, src[i:j], i})
+		case c == '\'':
+			end := strings.IndexByte(src[j:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			j += end + 1
+			toks = append(toks, exprToken{tokStr, src[i+1 : j-1], i})
+		case isIdentByte(c, false):
+			for j < len(src) && (isIdentByte(src[j], true) || src[j] == '.' && j+1 < len(src) && isIdentByte(src[j+1], false)) {
+				j++
+			}
+			toks = append(toks, exprToken{tokIdent, src[i:j], i})
+		case c == '$' && j < len(src) && isIdentByte(src[j], false):
+			for j < len(src) && isIdentByte(src[j], true) {
+				j++
+			}
+			toks = append(toks, exprToken{tokData, src[i+1 : j], i})
+		default:
+			op := ""
+			for _, candidate := range exprOps {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at offset %d", c, i)
+			}
+			j = i + len(op)
+			toks = append(toks, exprToken{tokOp, op, i})
+		}
+		i = j
+	}
+	return append(toks, exprToken{tokEOF, "end of expression", len(src)}), nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentByte(c byte, inner bool) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_' || inner && isDigit(c)
+}
+
+// exprCompiler is a recursive descent parser emitting exprFunc closures.
+type exprCompiler struct {
+	toks  []exprToken
+	pos   int
+	owner reflect.Type
+}
+
+func (c *exprCompiler) peek() exprToken {
+	return c.toks[c.pos]
+}
+
+func (c *exprCompiler) next() exprToken {
+	tok := c.toks[c.pos]
+	if tok.kind != tokEOF {
+		c.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of the given operators.
+func (c *exprCompiler) accept(ops ...string) (string, bool) {
+	tok := c.peek()
+	if tok.kind != tokOp {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			c.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (c *exprCompiler) expect(op string) error {
+	if _, ok := c.accept(op); !ok {
+		tok := c.peek()
+		return fmt.Errorf("%q expected at offset %d, got %q", op, tok.pos, tok.text)
+	}
+	return nil
+}
+
+func (c *exprCompiler) or() (exprFunc, error) {
+	return c.logical("||", c.and)
+}
+
+func (c *exprCompiler) and() (exprFunc, error) {
+	return c.logical("&&", c.not)
+}
+
+// logical compiles a chain of short-circuiting op operators.
+func (c *exprCompiler) logical(op string, operand func() (exprFunc, error)) (exprFunc, error) {
+	lhs, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := c.accept(op); !ok {
+			return lhs, nil
+		}
+		rhs, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		lhs = logicalOp(op, lhs, rhs)
+	}
+}
+
+func logicalOp(op string, lhs, rhs exprFunc) exprFunc {
+	return func(fl fieldLevel) (any, error) {
+		l, err := evalBool(lhs, fl, op)
+		if err != nil || l == (op == "||") {
+			return l, err
+		}
+		return evalBool(rhs, fl, op)
+	}
+}
+
+func evalBool(f exprFunc, fl fieldLevel, op string) (bool, error) {
+	v, err := f(fl)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("operand of %s is %T, not bool", op, v)
+	}
+	return b, nil
+}
+
+func (c *exprCompiler) not() (exprFunc, error) {
+	if _, ok := c.accept("!"); !ok {
+		return c.comparison()
+	}
+	operand, err := c.not()
+	if err != nil {
+		return nil, err
+	}
+	return func(fl fieldLevel) (any, error) {
+		b, err := evalBool(operand, fl, "!")
+		return !b, err
+	}, nil
+}
+
+func (c *exprCompiler) comparison() (exprFunc, error) {
+	lhs, err := c.sum()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := c.accept("==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return lhs, nil
+	}
+	rhs, err := c.sum()
+	if err != nil {
+		return nil, err
+	}
+	return binaryOp(lhs, rhs, func(l, r any) (any, error) {
+		cmp, err := compareValues(l, r, op)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case "==":
+			return cmp == 0, nil
+		case "!=":
+			return cmp != 0, nil
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+	}), nil
+}
+
+// compareValues returns -1, 0 or 1 as l is less than, equal to or greater
+// than r. Booleans only support equality operators.
+func compareValues(l, r any, op string) (int, error) {
+	switch l := l.(type) {
+	case string:
+		if r, ok := r.(string); ok {
+			return strings.Compare(l, r), nil
+		}
+	case bool:
+		if r, ok := r.(bool); ok && (op == "==" || op == "!=") {
+			if l == r {
+				return 0, nil
+			}
+			return 1, nil
+		}
+	default:
+		if lf, rf, ok := numericOperands(l, r); ok {
+			switch {
+			case lf < rf:
+				return -1, nil
+			case lf > rf:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	}
+	return 0, fmt.Errorf("cannot compare %T %s %T", l, op, r)
+}
+
+func (c *exprCompiler) sum() (exprFunc, error) {
+	return c.arithmetic(c.term, "+", "-")
+}
+
+func (c *exprCompiler) term() (exprFunc, error) {
+	return c.arithmetic(c.unary, "*", "/", "%")
+}
+
+// arithmetic compiles a left-associative chain of ops.
+func (c *exprCompiler) arithmetic(operand func() (exprFunc, error), ops ...string) (exprFunc, error) {
+	lhs, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := c.accept(ops...)
+		if !ok {
+			return lhs, nil
+		}
+		rhs, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		lhs = binaryOp(lhs, rhs, func(l, r any) (any, error) {
+			return arithmeticOp(op, l, r)
+		})
+	}
+}
+
+func binaryOp(lhs, rhs exprFunc, op func(l, r any) (any, error)) exprFunc {
+	return func(fl fieldLevel) (any, error) {
+		l, err := lhs(fl)
+		if err != nil {
+			return nil, err
+		}
+		r, err := rhs(fl)
+		if err != nil {
+			return nil, err
+		}
+		return op(l, r)
+	}
+}
+
+func arithmeticOp(op string, l, r any) (any, error) {
+	if ls, ok := l.(string); ok && op == "+" {
+		if rs, ok := r.(string); ok {
+			return ls + rs, nil
+		}
+	}
+	li, lInt := l.(int64)
+	ri, rInt := r.(int64)
+	if lInt && rInt {
+		switch op {
+		case "+":
+			return li + ri, nil
+		case "-":
+			return li - ri, nil
+		case "*":
+			return li * ri, nil
+		}
+		if ri == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		if op == "/" {
+			return li / ri, nil
+		}
+		return li % ri, nil
+	}
+	lf, rf, ok := numericOperands(l, r)
+	if !ok || op == "%" {
+		return nil, fmt.Errorf("invalid operation %T %s %T", l, op, r)
+	}
+	switch op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	}
+	return lf / rf, nil
+}
+
+func numericOperands(l, r any) (float64, float64, bool) {
+	lf, lOk := toFloat(l)
+	rf, rOk := toFloat(r)
+	return lf, rf, lOk && rOk
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func (c *exprCompiler) unary() (exprFunc, error) {
+	if _, ok := c.accept("-"); !ok {
+		return c.primary()
+	}
+	operand, err := c.unary()
+	if err != nil {
+		return nil, err
+	}
+	return func(fl fieldLevel) (any, error) {
+		v, err := operand(fl)
+		if err != nil {
+			return nil, err
+		}
+		return arithmeticOp("-", int64(0), v)
+	}, nil
+}
+
+func (c *exprCompiler) primary() (exprFunc, error) {
+	tok := c.next()
+	switch tok.kind {
+	case tokNum:
+		var v any
+		var err error
+		if strings.Contains(tok.text, ".") {
+			v, err = strconv.ParseFloat(tok.text, 64)
+		} else {
+			v, err = strconv.ParseInt(tok.text, 10, 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at offset %d", tok.text, tok.pos)
+		}
+		return constant(v), nil
+	case tokStr:
+		return constant(tok.text), nil
+	case tokIdent:
+		switch tok.text {
+		case "true", "false":
+			return constant(tok.text == "true"), nil
+		case "len":
+			return c.length()
+		}
+		return c.field(tok)
+	case tokData:
+		return data(tok.text), nil
+	case tokOp:
+		if tok.text == "(" {
+			inner, err := c.or()
+			if err != nil {
+				return nil, err
+			}
+			return inner, c.expect(")")
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.pos)
+}
+
+func constant(v any) exprFunc {
+	return func(fieldLevel) (any, error) {
+		return v, nil
+	}
+}
+
+// data compiles a reference to the value of key in the call data.
+func data(key string) exprFunc {
+	return func(fl fieldLevel) (any, error) {
+		v, ok := fl.dataValue(key)
+		if !ok || v == nil {
+			return nil, fmt.Errorf("no data for $%s", key)
+		}
+		return exprValue(reflect.ValueOf(v))
+	}
+}
+
+// length compiles len(x), where x is a field or a string.
+func (c *exprCompiler) length() (exprFunc, error) {
+	if err := c.expect("("); err != nil {
+		return nil, err
+	}
+	// Tokens other than the end of the expression are followed by one.
+	if tok := c.peek(); tok.kind == tokIdent && c.toks[c.pos+1].kind == tokOp && c.toks[c.pos+1].text == ")" {
+		ref, err := newFieldRef(c.owner, tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("%v at offset %d", err, tok.pos)
+		}
+		c.next()
+		return func(fl fieldLevel) (any, error) {
+			v, err := ref.value(fl)
+			if err != nil {
+				return nil, err
+			}
+			switch v.Kind() {
+			case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+				return int64(v.Len()), nil
+			}
+			return nil, fmt.Errorf("invalid argument %s for len", v.Type())
+		}, c.expect(")")
+	}
+	str, err := c.or()
+	if err != nil {
+		return nil, err
+	}
+	return func(fl fieldLevel) (any, error) {
+		v, err := str(fl)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := v.(string); ok {
+			return int64(len(v)), nil
+		}
+		return nil, fmt.Errorf("invalid argument %T for len", v)
+	}, c.expect(")")
+}
+
+// field compiles a field reference.
+func (c *exprCompiler) field(tok exprToken) (exprFunc, error) {
+	ref, err := newFieldRef(c.owner, tok.text)
+	if err != nil {
+		return nil, fmt.Errorf("%v at offset %d", err, tok.pos)
+	}
+	if ref.typ != nil {
+		if _, err := exprValue(reflect.Zero(ref.typ)); err != nil {
+			return nil, fmt.Errorf("field %q: %v", tok.text, err)
+		}
+	}
+	return func(fl fieldLevel) (any, error) {
+		v, err := ref.value(fl)
+		if err != nil {
+			return nil, err
+		}
+		return exprValue(v)
+	}, nil
+}
+
+// exprValue converts a field to an expression value.
+func exprValue(v reflect.Value) (any, error) {
+	switch k := v.Kind(); {
+	case isIntKind(k):
+		return v.Int(), nil
+	case isUintKind(k):
+		return int64(v.Uint()), nil
+	}
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	}
+	return nil, fmt.Errorf("type %s is not supported", v.Type())
+}
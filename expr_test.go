@@ -0,0 +1,127 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpr(t *testing.T) {
+	type account struct {
+		Age     int
+		Country string
+		Score   float64
+		Admin   bool
+		Tags    []string
+		Limit   uint8
+	}
+	acc := account{Age: 20, Country: "US", Score: 4.5, Tags: []string{"a", "b"}, Limit: 3}
+	tests := []struct {
+		expr    string
+		want    bool
+		wantErr string
+	}{
+		{expr: "Age >= 18 && Country == 'US'", want: true},
+		{expr: "Age >= 21 || Country == 'DE'", want: false},
+		{expr: "!Admin && (Age - 10) * 2 == 20", want: true},
+		{expr: "Score > 4 && Score < 5.0", want: true},
+		{expr: "len(Tags) == 2 && len(Country + 'A') == 3", want: true},
+		{expr: "Limit % 2 == 1 && -Limit < 0", want: true},
+		{expr: "Age / 0 == 1", wantErr: "division by zero"},
+		{expr: "Age == 'US'", wantErr: "cannot compare int64 == string"},
+		{expr: "Age + 1", wantErr: "result is int64, not bool"},
+		{expr: "Name == 'x'", wantErr: `field reference "Name": unknown field "Name"`},
+		{expr: "Age.Years > 1", wantErr: `field reference "Age.Years": int is not a struct at offset 0`},
+		{expr: "Age > 1 Age", wantErr: `unexpected "Age" at offset 8`},
+		{expr: "(Age > 1", wantErr: `")" expected at offset 8, got "end of expression"`},
+		{expr: "Country == 'US", wantErr: "unterminated string at offset 11"},
+		{expr: "Age # 1", wantErr: `unexpected '#' at offset 4`},
+		{expr: "len(", wantErr: `unexpected "end of expression" at offset 4`},
+		{expr: "len(Country", wantErr: `")" expected at offset 11, got "end of expression"`},
+		{expr: "Age >", wantErr: `unexpected "end of expression" at offset 5`},
+		{expr: "!", wantErr: `unexpected "end of expression" at offset 1`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			prog, err := compileExpr(tt.expr, reflect.TypeOf(acc))
+			var got bool
+			if err == nil {
+				s := reflect.ValueOf(acc)
+				got, err = prog.eval(fieldLevel{parent: s, root: s})
+			}
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.ErrorContains(t, err, ErrInvalidValidatorSyntax.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateExpr(t *testing.T) {
+	type signup struct {
+		Age     int      `validate:"expr:Age >= 18 && Country == 'US'"`
+		Country string   `validate:"len:2"`
+		Emails  []string `validate:"expr:len(Emails) > 0;min:3"`
+	}
+	assert.NoError(t, Validate(signup{Age: 18, Country: "US", Emails: []string{"a@b"}}))
+	assert.EqualError(t, Validate(signup{Age: 17, Country: "US"}),
+		`.Age: validation failed for "expr" tag; .Emails: validation failed for "expr" tag`)
+	assert.EqualError(t, Validate(signup{Age: 18, Country: "US", Emails: []string{"a", "b@c"}}),
+		`.Emails[0]: validation failed for "min" tag`)
+
+	err := Validate(struct {
+		Age int `validate:"expr:Agee > 1"`
+	}{})
+	assert.ErrorContains(t, err, `unknown field "Agee"`)
+	err = Validate(struct {
+		Age int `validate:"expr:len("`
+	}{})
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+}
+
+func TestValidateExprFieldReferences(t *testing.T) {
+	type address struct {
+		Country string
+		Zip     string `validate:"expr:len(Zip) == 5 || Country != 'US'"`
+	}
+	type note struct {
+		Text string `validate:"expr:len(Text) <= Limits.MaxNote"`
+	}
+	type shipment struct {
+		address
+		Billing *address
+		Notes   []note
+		Limits  struct {
+			MaxNote int
+		}
+		Lat int `validate:"expr:Lat >= -90 && Billing.Country == Country"`
+	}
+	newShipment := func() shipment {
+		s := shipment{
+			address: address{"US", "12345"},
+			Billing: &address{"US", "54321"},
+			Notes:   []note{{"abc"}},
+			Lat:     10,
+		}
+		s.Limits.MaxNote = 5
+		return s
+	}
+	assert.NoError(t, Validate(newShipment()))
+
+	s := newShipment()
+	s.Notes = append(s.Notes, note{"abcdef"})
+	s.Zip = "1234"
+	assert.EqualError(t, Validate(s), `.address.Zip: validation failed for "expr" tag`+
+		`; .Notes[1].Text: validation failed for "expr" tag`)
+
+	s = newShipment()
+	s.Billing.Country = "DE"
+	assert.EqualError(t, Validate(s), `.Lat: validation failed for "expr" tag`)
+
+	s.Billing = nil
+	assert.ErrorContains(t, Validate(s), `field reference "Billing.Country": nil pointer`)
+}
@@ -0,0 +1,229 @@
+package validate
+
+import "reflect"
+
+// fastField is a field of a flat struct, checked by the fast path: its
+// rules are bound to the kind of the field when building the plan, so
+// that checking the field takes neither tag nor type lookups.
+type fastField struct {
+	index  int
+	checks []fastCheck
+	// normalize is set when the last rule of the field normalizes it,
+	// which only the regular way does, see WithNormalize.
+	normalize bool
+	// nested is set on fields holding structs, see nestedKind, whose
+	// structs are checked by the fast path when they are flat too.
+	nested bool
+}
+
+// fastCheck is a rule of a fastField.
+type fastCheck struct {
+	param param
+	// omitempty skips the checks following it when the field is zero.
+	omitempty bool
+	rule      *rule
+	kind      fastKind
+}
+
+// fastKind is the assertion of a rule a fastCheck calls, by the kind of
+// its field.
+type fastKind uint8
+
+const (
+	// fastFail fails the rules missing their parameter.
+	fastFail fastKind = iota
+	fastInt
+	fastUint
+	fastFloat
+	fastString
+	fastBool
+)
+
+// newFastFields returns the fast fields of plan p, or false when its
+// struct type is not flat: every field must be exported and either hold
+// structs without having rules, see nestedKind, or be of a basic kind
+// without methods, like int or string, and have only value rules applying
+// to its kind, without modifiers nor rules which depend on the call, such
+// as those of groups and roles. Normalizing rules must come last.
+func newFastFields(p *structPlan, o *options) ([]fastField, bool) {
+	if isScalar(p.typ, o) {
+		return nil, false
+	}
+	var res []fastField
+	for i := range p.fields {
+		field := &p.fields[i]
+		t := p.typ.Field(i).Type
+		tag := &field.tag
+		switch {
+		case field.unexported:
+			return nil, false
+		case nestedKind(t) && tag.empty() && !field.whole:
+			res = append(res, fastField{index: i, nested: true})
+			continue
+		case !basicKind(t):
+			return nil, false
+		case tag.empty():
+			continue
+		}
+		if tag.err != nil || tag.sectioned() {
+			return nil, false
+		}
+		f := fastField{index: i}
+		for j, tr := range tag.rules {
+			if tr.key == omitemptyTag {
+				f.checks = append(f.checks, fastCheck{omitempty: true})
+				continue
+			}
+			r, ok := rules[tr.key]
+			if !ok || tr.warn || tr.role != "" || r.warn || r.modify != nil ||
+				r.assertField != nil || r.assertElems != nil {
+				return nil, false
+			}
+			if r.normalize != nil {
+				if j < len(tag.rules)-1 {
+					return nil, false
+				}
+				f.normalize = true
+			}
+			if _, ref := dataRef(tr.param.val); ref {
+				return nil, false
+			}
+			kind, ok := kindCheck(&r, t.Kind(), tr.param)
+			if !ok {
+				return nil, false
+			}
+			f.checks = append(f.checks, fastCheck{param: tr.param, rule: &r, kind: kind})
+		}
+		res = append(res, f)
+	}
+	return res, true
+}
+
+// basicKind reports whether t is a boolean, numeric or string type
+// without methods, whose values rules check by kind alone.
+func basicKind(t reflect.Type) bool {
+	switch k := t.Kind(); {
+	case k == reflect.Bool, k == reflect.String, isIntKind(k), isUintKind(k), isFloatKind(k):
+		return t.NumMethod() == 0 && reflect.PointerTo(t).NumMethod() == 0
+	}
+	return false
+}
+
+// nestedKind reports whether t is a struct type without methods, or a
+// slice or an array of such structs.
+func nestedKind(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t.NumMethod() == 0 && reflect.PointerTo(t).NumMethod() == 0
+}
+
+// kindCheck returns the assertion of r checking values of kind k, as
+// rule.Validate would dispatch them, or false if r does not apply to them.
+func kindCheck(r *rule, k reflect.Kind, p param) (fastKind, bool) {
+	if len(p.val) == 0 && !r.noParam {
+		return fastFail, true
+	}
+	switch {
+	case isIntKind(k) && r.assertInt != nil:
+		return fastInt, true
+	case isUintKind(k) && r.assertUint != nil:
+		return fastUint, true
+	case isFloatKind(k) && r.assertFloat != nil:
+		return fastFloat, true
+	case k == reflect.String && r.assertStr != nil:
+		return fastString, true
+	case k == reflect.Bool && r.assertBool != nil:
+		return fastBool, true
+	}
+	return fastFail, false
+}
+
+// check applies the rule of fc to v, with p the parameter of fc bound to
+// the call.
+func (fc *fastCheck) check(v reflect.Value, p param) (bool, error) {
+	switch fc.kind {
+	case fastInt:
+		return fc.rule.assertInt(v.Int(), p)
+	case fastUint:
+		return fc.rule.assertUint(v.Uint(), p)
+	case fastFloat:
+		return fc.rule.assertFloat(v.Float(), p)
+	case fastString:
+		return fc.rule.assertStr(v.String(), p)
+	case fastBool:
+		return fc.rule.assertBool(v.Bool(), p)
+	}
+	return false, nil
+}
+
+// fast reports whether the call may use the fast path, which does not
+// observe the fields passing their rules.
+func (c *validation) fast() bool {
+	return c.outcomes == nil && c.opts.trace == nil && c.only == nil && c.except == nil &&
+		len(c.opts.unwrappers) == 0
+}
+
+// fastValid reports whether flat struct v, of plan p, at depth passes its
+// rules. When it does not, v is validated again the regular way to report
+// the failures.
+func (c *validation) fastValid(v reflect.Value, p *structPlan, depth int) bool {
+	if c.deeper(depth, len(p.fields)) {
+		return false
+	}
+	for _, f := range p.fast {
+		if f.normalize && c.opts.normalize {
+			return false
+		}
+		fv := v.Field(f.index)
+		if f.nested {
+			if !c.fastNested(fv, depth+1) {
+				return false
+			}
+			continue
+		}
+		for i := range f.checks {
+			fc := &f.checks[i]
+			if fc.omitempty {
+				if fv.IsZero() {
+					break
+				}
+				continue
+			}
+			param := fc.param
+			param.opts, param.ctx = &c.opts, c.ctx
+			if ok, err := fc.check(fv, param); !ok || err != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// fastNested reports whether the structs held by v, a struct or a slice
+// or an array of structs at depth, are flat and pass their rules.
+func (c *validation) fastNested(v reflect.Value, depth int) bool {
+	if v.Kind() == reflect.Struct {
+		return c.fastStruct(v, depth)
+	}
+	if c.deeper(depth, v.Len()) {
+		return false
+	}
+	for i := 0; i < v.Len(); i++ {
+		if !c.fastStruct(v.Index(i), depth+1) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *validation) fastStruct(v reflect.Value, depth int) bool {
+	p := c.cache.plan(v.Type(), &c.opts)
+	return p.flat && c.fastValid(v, p, depth)
+}
+
+// deeper reports whether validating the n values held by a value at depth
+// exceeds the maximum depth.
+func (c *validation) deeper(depth, n int) bool {
+	return c.opts.maxDepth > 0 && depth >= c.opts.maxDepth && n > 0
+}
@@ -0,0 +1,141 @@
+package validate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type flatRecord struct {
+	ID       int     `validate:"min:1"`
+	Name     string  `validate:"required;max:32"`
+	Kind     string  `validate:"in:a,b,c"`
+	Email    string  `validate:"omitempty;email"`
+	Quantity uint    `validate:"max:100"`
+	Price    float64 `validate:"min:0"`
+	Active   bool    `validate:"eq:true"`
+	Code     string  `validate:"len:3"`
+	Note     string
+	Level    int8 `validate:"in:1,2,3"`
+}
+
+func TestFastPath(t *testing.T) {
+	type age int
+	type named struct {
+		Age age `validate:"min:18"`
+	}
+	for _, tt := range []struct {
+		name string
+		s    any
+		flat bool
+	}{
+		{"flat", flatRecord{}, true},
+		{"named kinds", named{}, true},
+		{"nested struct", struct{ R flatRecord }{}, true},
+		{"nested slice", struct{ R []flatRecord }{}, true},
+		{"nested struct with rules", struct {
+			R flatRecord `validate:"required"`
+		}{}, false},
+		{"nested pointer", struct{ R *flatRecord }{}, false},
+		{"nested time", struct{ T time.Time }{}, false},
+		{"pointer", struct {
+			N *int `validate:"min:1"`
+		}{}, false},
+		{"methods", struct {
+			D time.Duration `validate:"min:1s"`
+		}{}, false},
+		{"json number", struct {
+			N json.Number `validate:"min:1"`
+		}{}, false},
+		{"modifier", struct {
+			S string `validate:"trim|min:1"`
+		}{}, false},
+		{"field rule", struct {
+			A, B int `validate:"eqfield:A"`
+		}{}, false},
+		{"groups", struct {
+			S string `validate:"groups:create;required"`
+		}{}, false},
+		{"data reference", struct {
+			S string `validate:"in:$plans"`
+		}{}, false},
+		{"text marshaler", testBadText{}, false},
+		{"unsupported kind", struct {
+			B bool `validate:"min:1"`
+		}{}, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			o := defaultOptions()
+			assert.Equal(t, tt.flat, newPlanCache(0).plan(reflect.TypeOf(tt.s), &o).flat)
+		})
+	}
+
+	valid := flatRecord{ID: 1, Name: "n", Kind: "a", Price: 1, Active: true, Code: "abc", Level: 2}
+	assert.NoError(t, Validate(valid))
+	assert.NoError(t, Validate(&valid))
+	invalid := valid
+	invalid.Email, invalid.Quantity, invalid.Level = "x", 101, 4
+	assert.EqualError(t, Validate(invalid), `.Email: validation failed for "email" tag`+
+		`; .Quantity: validation failed for "max" tag`+
+		`; .Level: validation failed for "in" tag`)
+	res := Check(valid)
+	assert.True(t, res.Valid())
+	assert.Equal(t, Passed, res.Outcome(".Name"), "outcomes are recorded")
+	assert.NoError(t, Validate(named{Age: 18}))
+	assert.Error(t, Validate(named{Age: 17}))
+	assert.Error(t, Validate([]flatRecord{valid, {}}))
+	assert.Error(t, Validate(struct {
+		Records []flatRecord `validate:"in:x"`
+	}{[]flatRecord{valid}}), "tags of the fields holding a flat struct apply")
+
+	type order struct {
+		Records []flatRecord
+		Main    flatRecord
+	}
+	assert.NoError(t, Validate(order{[]flatRecord{valid, valid}, valid}))
+	assert.EqualError(t, Validate(order{[]flatRecord{valid, invalid}, valid}),
+		`.Records[1].Email: validation failed for "email" tag`+
+			`; .Records[1].Quantity: validation failed for "max" tag`+
+			`; .Records[1].Level: validation failed for "in" tag`)
+	assert.ErrorIs(t, Validate(order{Main: valid}, WithMaxDepth(2)), ErrMaxDepthExceeded)
+	assert.ErrorIs(t, Validate(order{Records: []flatRecord{valid}, Main: valid}, WithMaxDepth(3)), ErrMaxDepthExceeded)
+	assert.NoError(t, Validate(order{Records: []flatRecord{valid}, Main: valid}, WithMaxDepth(4)))
+	// Check takes the regular way.
+	assert.ErrorIs(t, Check(order{Records: []flatRecord{valid}, Main: valid}, WithMaxDepth(3)).Err(), ErrMaxDepthExceeded)
+	assert.NoError(t, Check(order{Records: []flatRecord{valid}, Main: valid}, WithMaxDepth(4)).Err())
+}
+
+func TestValidateAllocs(t *testing.T) {
+	valid := flatRecord{ID: 1, Name: "n", Kind: "a", Price: 1, Active: true, Code: "abc", Level: 2}
+	type order struct {
+		Records []flatRecord
+		Main    flatRecord
+		Counts  [2]struct {
+			N int `validate:"min:0"`
+		}
+	}
+	o := &order{Records: []flatRecord{valid, valid}, Main: valid}
+	v := New()
+	assert.NoError(t, v.Validate(o))
+	for _, s := range []any{&valid, o} {
+		allocs := testing.AllocsPerRun(100, func() {
+			if err := v.Validate(s); err != nil {
+				t.Fatal(err)
+			}
+		})
+		assert.Zero(t, allocs, "%T", s)
+	}
+}
+
+func BenchmarkValidateFlat(b *testing.B) {
+	s := flatRecord{ID: 1, Name: "n", Kind: "a", Price: 1, Active: true, Code: "abc", Level: 2}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := Validate(&s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
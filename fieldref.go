@@ -0,0 +1,104 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldRef is a dotted reference to a field used in a rule parameter, such
+// as Address.PostalCode. References are resolved against the struct holding
+// the validated field, falling back to the root struct passed to Validate.
+type fieldRef struct {
+	path string
+	// index holds the field index of each segment, for references which
+	// could be resolved against the owner type when compiling.
+	index [][]int
+	typ   reflect.Type
+}
+
+// newFieldRef resolves path against struct type owner. References which
+// do not start with a field of owner are left to be resolved against the
+// root struct at validation time.
+func newFieldRef(owner reflect.Type, path string) (*fieldRef, error) {
+	ref := &fieldRef{path: path}
+	t := owner
+	for i, name := range strings.Split(path, ".") {
+		if name == "" {
+			return nil, fmt.Errorf("invalid field reference %q", path)
+		}
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("field reference %q: %s is not a struct", path, t)
+		}
+		sf, ok := t.FieldByName(name)
+		if !ok {
+			if i == 0 {
+				return &fieldRef{path: path}, nil
+			}
+			return nil, fmt.Errorf("field reference %q: unknown field %q", path, name)
+		}
+		ref.index = append(ref.index, sf.Index)
+		t = sf.Type
+	}
+	ref.typ = t
+	return ref, nil
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// value returns the referenced field of the field being validated.
+func (r *fieldRef) value(fl fieldLevel) (reflect.Value, error) {
+	if r.index == nil {
+		return lookupField(fl.root, r.path)
+	}
+	v := fl.parent
+	for _, index := range r.index {
+		v = indirect(v)
+		if !v.IsValid() {
+			return reflect.Value{}, fmt.Errorf("field reference %q: nil pointer", r.path)
+		}
+		var err error
+		if v, err = v.FieldByIndexErr(index); err != nil {
+			return reflect.Value{}, fmt.Errorf("field reference %q: %v", r.path, err)
+		}
+	}
+	return v, nil
+}
+
+// lookupField resolves a dotted path against struct value s by name.
+func lookupField(s reflect.Value, path string) (reflect.Value, error) {
+	v := s
+	for _, name := range strings.Split(path, ".") {
+		v = indirect(v)
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("field reference %q: unknown field %q", path, name)
+		}
+		sf, ok := v.Type().FieldByName(name)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("field reference %q: unknown field %q", path, name)
+		}
+		var err error
+		if v, err = v.FieldByIndexErr(sf.Index); err != nil {
+			return reflect.Value{}, fmt.Errorf("field reference %q: %v", path, err)
+		}
+	}
+	return v, nil
+}
+
+// indirect dereferences pointers and interfaces, returning the zero Value
+// for nil ones.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,78 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// fixedWidth is the compiled parameter of the fixedwidth rule, e.g.
+// "fixedwidth:10,rightpad,padchar=0,numeric". The value must be exactly
+// width characters long. With rightpad its content is left aligned and
+// padded on the right, with leftpad the other way round. Padding is done
+// with spaces unless padchar is given. With numeric the content must be
+// made of digits.
+type fixedWidth struct {
+	width   int
+	pad     rune
+	padLeft bool
+	// aligned is set when padding is only allowed on one side.
+	aligned bool
+	numeric bool
+}
+
+func compileFixedWidth(p param, owner reflect.Type) (any, error) {
+	elems := p.list()
+	width, err := strconv.Atoi(elems[0])
+	if err != nil || width <= 0 {
+		return nil, fmt.Errorf("%w: fixedwidth: invalid width %q", ErrInvalidValidatorSyntax, elems[0])
+	}
+	fw := &fixedWidth{width: width, pad: ' '}
+	for _, opt := range elems[1:] {
+		switch opt {
+		case "rightpad", "leftpad":
+			fw.aligned, fw.padLeft = true, opt == "leftpad"
+		case "numeric":
+			fw.numeric = true
+		default:
+			pad, ok := strings.CutPrefix(opt, "padchar=")
+			if !ok || utf8.RuneCountInString(pad) != 1 {
+				return nil, fmt.Errorf("%w: fixedwidth: invalid option %q", ErrInvalidValidatorSyntax, opt)
+			}
+			fw.pad, _ = utf8.DecodeRuneInString(pad)
+		}
+	}
+	return fw, nil
+}
+
+func (fw *fixedWidth) check(val string) bool {
+	if utf8.RuneCountInString(val) != fw.width {
+		return false
+	}
+	content := val
+	switch {
+	case !fw.aligned:
+		content = strings.Trim(val, string(fw.pad))
+	case fw.padLeft:
+		// Content is right aligned: spaces may not trail it.
+		content = strings.TrimLeft(val, string(fw.pad))
+		if strings.HasSuffix(content, " ") {
+			return false
+		}
+	default:
+		content = strings.TrimRight(val, string(fw.pad))
+		if strings.HasPrefix(content, " ") {
+			return false
+		}
+	}
+	if fw.numeric {
+		for i := 0; i < len(content); i++ {
+			if content[i] < '0' || content[i] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedWidth(t *testing.T) {
+	tests := []struct {
+		tag   string
+		val   string
+		valid bool
+	}{
+		{"fixedwidth:5", "AB   ", true},
+		{"fixedwidth:5", "  AB ", true},
+		{"fixedwidth:5", "ABCD", false},
+		{"fixedwidth:5", "ABCDEF", false},
+		{"fixedwidth:4", "ÄÖÜß", true},
+		{"fixedwidth:5,rightpad", "AB   ", true},
+		{"fixedwidth:5,rightpad", "  AB ", false},
+		{"fixedwidth:5,rightpad", "     ", true},
+		{"fixedwidth:6,leftpad,padchar=0,numeric", "001230", true},
+		{"fixedwidth:6,leftpad,padchar=0,numeric", "000000", true},
+		{"fixedwidth:6,leftpad,padchar=0,numeric", "12 300", false},
+		{"fixedwidth:6,leftpad,padchar=0,numeric", "1230  ", false},
+		{"fixedwidth:6,leftpad,numeric", "  1230", true},
+		{"fixedwidth:6,leftpad,numeric", "1230  ", false},
+		{"fixedwidth:6,rightpad,padchar=*", "ABC***", true},
+	}
+	for _, tt := range tests {
+		err := Var(tt.val, tt.tag)
+		if tt.valid {
+			assert.NoError(t, err, "%s %q", tt.tag, tt.val)
+		} else {
+			assert.EqualError(t, err, `validation failed for "fixedwidth" tag`, "%s %q", tt.tag, tt.val)
+		}
+	}
+
+	for _, tag := range []string{"fixedwidth:0", "fixedwidth:x", "fixedwidth:5,center", "fixedwidth:5,padchar=ab"} {
+		assert.ErrorContains(t, Var("abcde", tag), "invalid validator syntax: fixedwidth: invalid", tag)
+	}
+}
@@ -0,0 +1,145 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// LatLng is a point in degrees of latitude and longitude.
+type LatLng struct {
+	Lat, Lng float64
+}
+
+// regions are the regions known to the within rule by default, as
+// bounding boxes.
+var regions = map[string][]LatLng{
+	// us is the contiguous United States.
+	"us": boundingBox(24.396308, -124.848974, 49.384358, -66.885444),
+	"ca": boundingBox(41.676556, -141.000000, 83.110626, -52.619408),
+}
+
+// boundingBox returns the polygon of the bounding box from south-west corner
+// (lat0, lng0) to north-east corner (lat1, lng1).
+func boundingBox(lat0, lng0, lat1, lng1 float64) []LatLng {
+	return []LatLng{{lat0, lng0}, {lat0, lng1}, {lat1, lng1}, {lat1, lng0}}
+}
+
+// WithRegion registers a region for the within rule: "within:name"
+// requires a coordinate pair to lie inside the polygon given by its
+// vertices. Polygons may not cross the antimeridian. A region replaces the
+// built-in or previously registered region of the same name.
+func WithRegion(name string, polygon ...LatLng) Option {
+	polygon = append([]LatLng(nil), polygon...)
+	return func(o *options) {
+		res := make(map[string][]LatLng, len(o.regions)+1)
+		for n, p := range o.regions {
+			res[n] = p
+		}
+		res[name] = polygon
+		o.regions = res
+	}
+}
+
+// region returns the polygon of the region named by p.
+func (p param) region() ([]LatLng, error) {
+	if polygon, ok := p.opts.regions[p.val]; ok {
+		return polygon, nil
+	}
+	if polygon, ok := regions[p.val]; ok {
+		return polygon, nil
+	}
+	return nil, fmt.Errorf("within: unknown region %q, see WithRegion", p.val)
+}
+
+// contains reports whether pt lies inside polygon, by counting the edges
+// crossed by a ray from pt towards increasing longitudes.
+func contains(polygon []LatLng, pt LatLng) bool {
+	in := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > pt.Lat) != (b.Lat > pt.Lat) &&
+			pt.Lng < a.Lng+(pt.Lat-a.Lat)*(b.Lng-a.Lng)/(b.Lat-a.Lat) {
+			in = !in
+		}
+	}
+	return in
+}
+
+// coordinates returns the coordinates held by struct v in fields named
+// Lat or Latitude and Lng, Lon or Longitude.
+func coordinates(v reflect.Value) (LatLng, error) {
+	lat, ok := coordinate(v, "Lat", "Latitude")
+	if !ok {
+		return LatLng{}, fmt.Errorf("%s has no numeric Lat field", v.Type())
+	}
+	lng, ok := coordinate(v, "Lng", "Lon", "Longitude")
+	if !ok {
+		return LatLng{}, fmt.Errorf("%s has no numeric Lng field", v.Type())
+	}
+	return LatLng{lat, lng}, nil
+}
+
+func coordinate(v reflect.Value, names ...string) (float64, bool) {
+	for _, name := range names {
+		f := v.FieldByName(name)
+		switch {
+		case isFloatKind(f.Kind()):
+			return f.Float(), true
+		case isIntKind(f.Kind()):
+			return float64(f.Int()), true
+		}
+	}
+	return 0, false
+}
+
+// geoRule returns a rule checking coordinate pairs, structs with Lat and
+// Lng fields, with check. It is a field rule so that the pair is checked
+// as a whole. Slices and arrays of pairs are checked elementwise and nil
+// pointers are left to required.
+func geoRule(name string, check func(pt LatLng, p param) (bool, error)) rule {
+	var assert func(v reflect.Value, p param) (bool, error)
+	assert = func(v reflect.Value, p param) (bool, error) {
+		v = indirect(v)
+		switch v.Kind() {
+		case reflect.Invalid:
+			return true, nil
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				if ok, err := assert(v.Index(i), p); !ok || err != nil {
+					return ok, err
+				}
+			}
+			return true, nil
+		case reflect.Struct:
+			pt, err := coordinates(v)
+			if err != nil {
+				return false, fmt.Errorf("%s: %v", name, err)
+			}
+			if !(-90 <= pt.Lat && pt.Lat <= 90 && -180 <= pt.Lng && pt.Lng <= 180) {
+				return false, nil
+			}
+			return check(pt, p)
+		}
+		return false, fmt.Errorf("%s: unsupported type %s", name, v.Type())
+	}
+	return rule{
+		assertField: func(fl fieldLevel, p param) (bool, error) {
+			return assert(fl.field, p)
+		},
+	}
+}
+
+// geoboundsRule requires latitudes from -90 to 90 and longitudes from
+// -180 to 180.
+var geoboundsRule = geoRule("geobounds", func(pt LatLng, p param) (bool, error) {
+	return true, nil
+})
+
+// withinRule requires coordinates to lie inside a region, e.g. within:us.
+var withinRule = geoRule("within", func(pt LatLng, p param) (bool, error) {
+	polygon, err := p.region()
+	if err != nil {
+		return false, err
+	}
+	return contains(polygon, pt), nil
+})
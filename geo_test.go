@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeo(t *testing.T) {
+	type Point struct {
+		Lat float64
+		Lng float64
+	}
+	type Place struct {
+		Latitude  float32
+		Longitude float32
+	}
+	type S struct {
+		Where  Point   `validate:"geobounds"`
+		Home   *Point  `validate:"within:us"`
+		Stops  []Point `validate:"within:us"`
+		Office Place   `validate:"within:ca"`
+	}
+	nyc := Point{40.7128, -74.0060}
+	ok := S{
+		Where:  Point{-90, 180},
+		Home:   &nyc,
+		Stops:  []Point{nyc, {37.7749, -122.4194}},
+		Office: Place{45.5017, -73.5673},
+	}
+	assert.NoError(t, Validate(ok))
+	assert.NoError(t, Validate(S{Office: ok.Office}))
+
+	bad := ok
+	bad.Where = Point{91, 0}
+	bad.Home = &Point{51.5074, -0.1278}
+	bad.Stops = []Point{nyc, {40, -200}}
+	bad.Office = Place{40.7128, -74.0060}
+	assert.EqualError(t, Validate(bad), `.Where: validation failed for "geobounds" tag`+
+		`; .Home: validation failed for "within" tag`+
+		`; .Stops: validation failed for "within" tag`+
+		`; .Office: validation failed for "within" tag`)
+
+	triangle := []LatLng{{0, 0}, {10, 0}, {0, 10}}
+	type T struct {
+		P Point `validate:"within:tri"`
+	}
+	assert.NoError(t, Validate(T{Point{2, 2}}, WithRegion("tri", triangle...)))
+	assert.EqualError(t, Validate(T{Point{6, 6}}, WithRegion("tri", triangle...)),
+		`.P: validation failed for "within" tag`)
+	assert.ErrorContains(t, Validate(T{}), `within: unknown region "tri"`)
+
+	type U struct {
+		P struct{ X, Y float64 } `validate:"geobounds"`
+	}
+	assert.ErrorContains(t, Validate(U{}), "has no numeric Lat field")
+}
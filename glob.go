@@ -0,0 +1,42 @@
+package validate
+
+import (
+	"path"
+	"strings"
+)
+
+// validGlob reports whether pattern is a valid path.Match pattern.
+func validGlob(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+var globRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		return validGlob(val), nil
+	},
+	noParam: true,
+}
+
+// doubleStarRule requires a gitignore-style pattern: path.Match patterns
+// separated by slashes, where "**" matches any number of directories and
+// must make up a whole element, e.g. "src/**/*.go". The pattern may be
+// negated by a leading "!", anchored by a leading slash and restricted to
+// directories by a trailing one.
+var doubleStarRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		val = strings.TrimPrefix(val, "!")
+		val = strings.TrimPrefix(val, "/")
+		val = strings.TrimSuffix(val, "/")
+		if val == "" {
+			return false, nil
+		}
+		for _, elem := range strings.Split(val, "/") {
+			if elem == "" || elem != "**" && (strings.Contains(elem, "**") || !validGlob(elem)) {
+				return false, nil
+			}
+		}
+		return true, nil
+	},
+	noParam: true,
+}
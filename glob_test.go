@@ -0,0 +1,36 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobRules(t *testing.T) {
+	for _, tt := range []struct {
+		val, tag string
+		ok       bool
+	}{
+		{"*.go", "glob", true},
+		{"logs/app-[0-9]?.log", "glob", true},
+		{`a\*b`, "glob", true},
+		{"[a-", "glob", false},
+		{`trailing\`, "glob", false},
+		{"src/**/*.go", "doublestar", true},
+		{"**/node_modules/", "doublestar", true},
+		{"!/build/**", "doublestar", true},
+		{"*.log", "doublestar", true},
+		{"src/**.go", "doublestar", false},
+		{"a//b", "doublestar", false},
+		{"docs/[a-", "doublestar", false},
+		{"/", "doublestar", false},
+		{"", "doublestar", false},
+	} {
+		err := Var(tt.val, tt.tag)
+		if tt.ok {
+			assert.NoError(t, err, "%q %s", tt.val, tt.tag)
+		} else {
+			assert.Error(t, err, "%q %s", tt.val, tt.tag)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package validate
+
+import (
+	"net/url"
+	"strings"
+)
+
+// shellMeta holds the characters a POSIX shell treats specially: the
+// metacharacters ending words, quotes, expansions, globbing, comments and
+// history expansion.
+const shellMeta = "|&;<>()$`\\\"' \t\n\r*?[]{}#~!\x00"
+
+// noShellMetaRule rejects strings with characters a shell interprets, for
+// values interpolated into commands.
+var noShellMetaRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		return !strings.ContainsAny(val, shellMeta), nil
+	},
+	noParam: true,
+}
+
+// pathTraversal reports whether path has a ".." element, with either
+// slash as separator, or a NUL byte.
+func pathTraversal(path string) bool {
+	if strings.IndexByte(path, 0) >= 0 {
+		return true
+	}
+	for _, elem := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if elem == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// noPathTraversalRule rejects strings which could escape the directory
+// they are joined to: with a ".." element, also once percent-decoded, or
+// with a NUL byte.
+var noPathTraversalRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		if pathTraversal(val) {
+			return false, nil
+		}
+		decoded, err := url.PathUnescape(val)
+		return err != nil || !pathTraversal(decoded), nil
+	},
+	noParam: true,
+}
@@ -0,0 +1,40 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuardRules(t *testing.T) {
+	for _, tt := range []struct {
+		val, tag string
+		ok       bool
+	}{
+		{"backup-2024_01.tar.gz", "noshellmeta", true},
+		{"user@example.com", "noshellmeta", true},
+		{"a; rm -rf /", "noshellmeta", false},
+		{"$(id)", "noshellmeta", false},
+		{"`id`", "noshellmeta", false},
+		{"a|b", "noshellmeta", false},
+		{"a b", "noshellmeta", false},
+		{"*.go", "noshellmeta", false},
+		{"line\nbreak", "noshellmeta", false},
+		{"reports/2024/q1.pdf", "nopathtraversal", true},
+		{"..hidden/file", "nopathtraversal", true},
+		{"a/../b", "nopathtraversal", false},
+		{"../etc/passwd", "nopathtraversal", false},
+		{`..\windows`, "nopathtraversal", false},
+		{"dir/..", "nopathtraversal", false},
+		{"%2e%2e%2fetc", "nopathtraversal", false},
+		{"file\x00.txt", "nopathtraversal", false},
+		{"100%", "nopathtraversal", true},
+	} {
+		err := Var(tt.val, tt.tag)
+		if tt.ok {
+			assert.NoError(t, err, "%q %s", tt.val, tt.tag)
+		} else {
+			assert.Error(t, err, "%q %s", tt.val, tt.tag)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package validate
+
+import "strings"
+
+// defaultUserAgentLen caps the length of user agents checked by the
+// useragent rule without parameter.
+const defaultUserAgentLen = 512
+
+// userAgent reports whether val is a sane User-Agent header value:
+// non-empty printable ASCII of at most max bytes.
+func userAgent(val string, max int) bool {
+	if val == "" || len(val) > max {
+		return false
+	}
+	for i := 0; i < len(val); i++ {
+		if val[i] < 0x20 || val[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptLanguage reports whether val is a language priority list as sent
+// in the Accept-Language header (RFC 9110, section 12.5.4), e.g.
+// "fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5".
+func acceptLanguage(val string) bool {
+	ranges := 0
+	for _, elem := range strings.Split(val, ",") {
+		elem = strings.Trim(elem, " \t")
+		if elem == "" {
+			// Empty list elements are allowed by the #rule.
+			continue
+		}
+		lang, weight, hasWeight := strings.Cut(elem, ";")
+		if !languageRange(strings.TrimRight(lang, " \t")) {
+			return false
+		}
+		if hasWeight && !qvalue(strings.TrimLeft(weight, " \t")) {
+			return false
+		}
+		ranges++
+	}
+	return ranges > 0
+}
+
+// languageRange reports whether s is "*" or a language range like
+// "en-US": 1*8ALPHA *("-" 1*8alphanum).
+func languageRange(s string) bool {
+	if s == "*" {
+		return true
+	}
+	for i, sub := range strings.Split(s, "-") {
+		if len(sub) < 1 || len(sub) > 8 {
+			return false
+		}
+		for j := 0; j < len(sub); j++ {
+			c := sub[j] | 0x20
+			if !('a' <= c && c <= 'z' || i > 0 && '0' <= sub[j] && sub[j] <= '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// qvalue reports whether s is a weight like "q=0.8": a number from 0 to 1
+// with up to three decimals.
+func qvalue(s string) bool {
+	q, ok := strings.CutPrefix(s, "q=")
+	if !ok {
+		if q, ok = strings.CutPrefix(s, "Q="); !ok {
+			return false
+		}
+	}
+	whole, frac, _ := strings.Cut(q, ".")
+	if whole != "0" && whole != "1" || len(frac) > 3 {
+		return false
+	}
+	for i := 0; i < len(frac); i++ {
+		if frac[i] < '0' || frac[i] > '9' || whole == "1" && frac[i] != '0' {
+			return false
+		}
+	}
+	return true
+}
+
+var userAgentRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		return userAgent(val, p.compiled.(int)), nil
+	},
+	compile: compileLimit("useragent", defaultUserAgentLen),
+	noParam: true,
+}
+
+var acceptLanguageRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		return acceptLanguage(val), nil
+	},
+	noParam: true,
+}
@@ -0,0 +1,48 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderRules(t *testing.T) {
+	tests := []struct {
+		tag, val string
+		valid    bool
+	}{
+		{"useragent", "Mozilla/5.0 (X11; Linux x86_64) Firefox/128.0", true},
+		{"useragent", "curl/8.5.0", true},
+		{"useragent", "", false},
+		{"useragent", "bot\r\nX-Injected: 1", false},
+		{"useragent", "agent/ü", false},
+		{"useragent", strings.Repeat("a", 513), false},
+		{"useragent:10", "curl/8.5.0", true},
+		{"useragent:9", "curl/8.5.0", false},
+		{"accept_language", "fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5", true},
+		{"accept_language", "en-US", true},
+		{"accept_language", "*", true},
+		{"accept_language", "zh-Hant-TW;q=1.000,en ; q=0", true},
+		{"accept_language", "en,,de", true},
+		{"accept_language", "", false},
+		{"accept_language", " , ", false},
+		{"accept_language", "en;q=1.5", false},
+		{"accept_language", "en;q=0.1234", false},
+		{"accept_language", "en;level=1", false},
+		{"accept_language", "1en", false},
+		{"accept_language", "en-toolongsubtag", false},
+		{"accept_language", "en_US", false},
+	}
+	for _, tt := range tests {
+		err := Var(tt.val, tt.tag)
+		if tt.valid {
+			assert.NoError(t, err, "%s %q", tt.tag, tt.val)
+		} else {
+			assert.Error(t, err, "%s %q", tt.tag, tt.val)
+		}
+	}
+	assert.ErrorIs(t, Var("curl", "useragent:x"), ErrInvalidValidatorSyntax)
+	o := defaultOptions()
+	assert.ErrorIs(t, newFieldTag("useragent:x", nil, &o).err, ErrInvalidValidatorSyntax, "rejected when building plans")
+}
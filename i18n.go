@@ -0,0 +1,140 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Translator renders the message of a failure in a locale, such as "en" or
+// "pt-BR". It reports false when it has no message for the failure.
+type Translator interface {
+	Translate(locale string, fe FieldError) (string, bool)
+}
+
+// translationData is what translation templates are executed with.
+type translationData struct {
+	FieldError
+	// Field is the path of the failed value without its leading dot,
+	// e.g. Items[2].Name, or "value" for values given to Var.
+	Field string
+}
+
+// catalog holds the translations registered with RegisterTranslation by
+// locale and rule. A translation for rule "*" applies to the rules of its
+// locale without one.
+type catalog struct {
+	mu    sync.RWMutex
+	texts map[string]map[string]*template.Template
+}
+
+var translations = &catalog{texts: make(map[string]map[string]*template.Template)}
+
+// englishTranslations is the default English catalog.
+var englishTranslations = map[string]string{
+	"*":                `{{.Field}} failed the {{.Rule}} rule`,
+	"required":         `{{.Field}} is required`,
+	"min":              `{{.Field}} must be at least {{.Param}}`,
+	"max":              `{{.Field}} must be at most {{.Param}}`,
+	"gt":               `{{.Field}} must be greater than {{.Param}}`,
+	"lt":               `{{.Field}} must be less than {{.Param}}`,
+	"len":              `{{.Field}} must have a length of {{.Param}}`,
+	"eq":               `{{.Field}} must be equal to {{.Param}}`,
+	"in":               `{{.Field}} must be one of {{.Param}}{{with .Suggestion}}, did you mean "{{.}}"?{{end}}`,
+	"alpha":            `{{.Field}} must contain only letters`,
+	"email":            `{{.Field}} must be a valid email address`,
+	"url":              `{{.Field}} must be a valid URL`,
+	"phone":            `{{.Field}} must be a valid phone number`,
+	"eqfield":          `{{.Field}} must be equal to {{.Param}}`,
+	"nefield":          `{{.Field}} must differ from {{.Param}}`,
+	"gtfield":          `{{.Field}} must be greater than {{.Param}}`,
+	"ltfield":          `{{.Field}} must be less than {{.Param}}`,
+	"required_if":      `{{.Field}} is required`,
+	"required_unless":  `{{.Field}} is required`,
+	"required_with":    `{{.Field}} is required when {{.Param}} is set`,
+	"required_without": `{{.Field}} is required when {{.Param}} is not set`,
+	"excluded_with":    `{{.Field}} must be empty when {{.Param}} is set`,
+	"excluded_if":      `{{.Field}} must be empty`,
+	"unique_by":        `{{.Field}} repeats the {{.Param}} of an earlier element`,
+	"deprecated":       `{{.Field}} uses the deprecated value {{.Value}}`,
+}
+
+func init() {
+	for rule, text := range englishTranslations {
+		if err := RegisterTranslation("en", rule, text); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterTranslation registers the message of the failures of rule in
+// locale, as a text/template executed with the FieldError along with
+// Field, its path without the leading dot. The English message of min is
+// "{{.Field}} must be at least {{.Param}}". Rule "*" gives the message of
+// the rules of the locale without their own. Registering a translation
+// again replaces it.
+func RegisterTranslation(locale, rule, text string) error {
+	tmpl, err := template.New(locale + "/" + rule).Parse(text)
+	if err != nil {
+		return err
+	}
+	translations.mu.Lock()
+	defer translations.mu.Unlock()
+	if translations.texts[locale] == nil {
+		translations.texts[locale] = make(map[string]*template.Template)
+	}
+	translations.texts[locale][rule] = tmpl
+	return nil
+}
+
+// Translate renders fe with the translations registered for locale, for
+// its language, e.g. "pt" for "pt-BR", or else for English.
+func (c *catalog) Translate(locale string, fe FieldError) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	lang, _, _ := strings.Cut(locale, "-")
+	for _, loc := range []string{locale, lang, "en"} {
+		texts := c.texts[loc]
+		tmpl, ok := texts[fe.Rule]
+		if !ok {
+			tmpl, ok = texts["*"]
+		}
+		if !ok {
+			continue
+		}
+		data := translationData{FieldError: fe, Field: fieldName(fe.Path)}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			return "", false
+		}
+		return sb.String(), true
+	}
+	return "", false
+}
+
+// Translate returns v with the messages of failed rules rendered in locale
+// by the translations registered with RegisterTranslation. Errors which
+// are not rule failures are kept as is.
+func (v ValidationErrors) Translate(locale string) ValidationErrors {
+	return v.TranslateWith(translations, locale)
+}
+
+// TranslateWith returns v with the messages of failed rules rendered in
+// locale by t. Messages t has no translation for are kept as is, and so
+// are messages set by the msg pseudo-rule of a tag or by RegisterMessage,
+// which take precedence over translations.
+func (v ValidationErrors) TranslateWith(t Translator, locale string) ValidationErrors {
+	res := make(ValidationErrors, len(v))
+	for i, fe := range v {
+		res[i] = fe
+		var custom customMessage
+		if fe.Rule == "" || errors.As(fe.Err, &custom) {
+			continue
+		}
+		if msg, ok := t.Translate(locale, fe); ok {
+			res[i].Err = errors.New(msg)
+		}
+	}
+	return res
+}
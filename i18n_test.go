@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type upperTranslator struct{}
+
+func (upperTranslator) Translate(locale string, fe FieldError) (string, bool) {
+	if fe.Rule != "min" {
+		return "", false
+	}
+	return locale + ": " + fe.Code, true
+}
+
+func TestTranslate(t *testing.T) {
+	type S struct {
+		Name  string `validate:"required"`
+		Age   int    `validate:"min:18"`
+		Code  string `validate:"alpha"`
+		Token string `validate:"noshellmeta"`
+	}
+	var valErrs ValidationErrors
+	assert.True(t, errors.As(Validate(S{Age: 3, Code: "1", Token: "a;b"}), &valErrs))
+
+	msgs := func(errs ValidationErrors) []string {
+		var res []string
+		for _, fe := range errs {
+			res = append(res, fe.Error())
+		}
+		return res
+	}
+	assert.Equal(t, []string{
+		"Name is required",
+		"Age must be at least 18",
+		"Code must contain only letters",
+		"Token failed the noshellmeta rule",
+	}, msgs(valErrs.Translate("en")))
+
+	assert.NoError(t, RegisterTranslation("pt", "required", "{{.Field}} é obrigatório"))
+	assert.NoError(t, RegisterTranslation("pt-BR", "min", "{{.Field}} deve ser no mínimo {{.Param}}"))
+	translated := valErrs.Translate("pt-BR")
+	assert.Equal(t, []string{
+		"Name é obrigatório",
+		"Age deve ser no mínimo 18",
+		"Code must contain only letters",
+		"Token failed the noshellmeta rule",
+	}, msgs(translated))
+	assert.Equal(t, ".Age", translated[1].Path)
+	assert.True(t, errors.Is(translated, RuleFailed("min")))
+	assert.Equal(t, `.Name: validation failed for "required" tag`, valErrs[0].Error())
+
+	assert.Equal(t, []string{"value must be at least 5"},
+		msgs(ValidationErrors{{Err: errors.New("x"), Rule: "min", Param: "5"}}.Translate("en")[:1]))
+
+	assert.Equal(t, []string{
+		`.Name: validation failed for "required" tag`,
+		"fr: VAL_MIN",
+	}, msgs(valErrs.TranslateWith(upperTranslator{}, "fr")[:2]))
+
+	internal := ValidationErrors{{Err: ErrInvalidValidatorSyntax}}
+	assert.Equal(t, internal, internal.Translate("en"))
+	assert.Error(t, RegisterTranslation("en", "min", "{{.Field"))
+}
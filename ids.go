@@ -0,0 +1,92 @@
+package validate
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// IDPolicy tells what identifiers like idempotency keys and request IDs
+// look like. See WithIdempotencyKeyPolicy and WithRequestIDPolicy.
+type IDPolicy struct {
+	// MinLen and MaxLen bound the length of identifiers, in characters.
+	MinLen, MaxLen int
+	// Charset lists the characters allowed besides ASCII letters and
+	// digits.
+	Charset string
+	// MinEntropy is the minimum Shannon entropy of identifiers, in bits,
+	// estimated from the frequencies of their characters. It rejects keys
+	// like "aaaaaaaaaaaaaaaa" or "1111-2222-1111-2222".
+	MinEntropy float64
+}
+
+var (
+	// DefaultIdempotencyKeyPolicy is checked by the idempotency_key rule
+	// unless WithIdempotencyKeyPolicy is given. UUIDs and random keys of
+	// 16 or more characters pass.
+	DefaultIdempotencyKeyPolicy = IDPolicy{MinLen: 16, MaxLen: 255, Charset: "-_.:", MinEntropy: 48}
+	// DefaultRequestIDPolicy is checked by the requestid rule unless
+	// WithRequestIDPolicy is given. UUIDs, ULIDs, trace IDs and prefixed
+	// IDs like "req_8f2a9c1d" pass.
+	DefaultRequestIDPolicy = IDPolicy{MinLen: 8, MaxLen: 128, Charset: "-_.:", MinEntropy: 16}
+)
+
+// WithIdempotencyKeyPolicy sets the policy checked by the idempotency_key
+// rule.
+func WithIdempotencyKeyPolicy(p IDPolicy) Option {
+	return func(o *options) {
+		o.idempotencyKeys = &p
+	}
+}
+
+// WithRequestIDPolicy sets the policy checked by the requestid rule.
+func WithRequestIDPolicy(p IDPolicy) Option {
+	return func(o *options) {
+		o.requestIDs = &p
+	}
+}
+
+// check reports whether id follows the policy.
+func (p *IDPolicy) check(id string) bool {
+	n := utf8.RuneCountInString(id)
+	if n < p.MinLen || p.MaxLen > 0 && n > p.MaxLen {
+		return false
+	}
+	for _, r := range id {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || strings.ContainsRune(p.Charset, r)) {
+			return false
+		}
+	}
+	return entropy(id) >= p.MinEntropy
+}
+
+// entropy estimates the Shannon entropy of s, in bits, from the
+// frequencies of its characters.
+func entropy(s string) float64 {
+	counts := make(map[rune]int)
+	n := 0
+	for _, r := range s {
+		counts[r]++
+		n++
+	}
+	var bits float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		bits -= float64(c) * math.Log2(p)
+	}
+	return bits
+}
+
+// idRule returns a rule checking strings against the policy returned by
+// policy, or def when it returns nil.
+func idRule(policy func(o *options) *IDPolicy, def *IDPolicy) rule {
+	return rule{
+		assertStr: func(val string, p param) (bool, error) {
+			if pol := policy(p.opts); pol != nil {
+				return pol.check(val), nil
+			}
+			return def.check(val), nil
+		},
+		noParam: true,
+	}
+}
@@ -0,0 +1,48 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDRules(t *testing.T) {
+	tests := []struct {
+		tag, val string
+		valid    bool
+	}{
+		{"idempotency_key", "550e8400-e29b-41d4-a716-446655440000", true},
+		{"idempotency_key", "k7Qx9LmP2vRt8ZwB", true},
+		{"idempotency_key", "aaaaaaaaaaaaaaaaaaaaaaaa", false},
+		{"idempotency_key", "1212121212121212", false},
+		{"idempotency_key", "short-key", false},
+		{"idempotency_key", "550e8400 e29b 41d4 a716 446655440000", false},
+		{"requestid", "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"requestid", "req_8f2a9c1d", true},
+		{"requestid", "4bf92f3577b34da6a3ce929d0e0e4736", true},
+		{"requestid", "00000000", false},
+		{"requestid", "req/8f2a9c1d", false},
+		{"requestid", "abc", false},
+	}
+	for _, tt := range tests {
+		err := Var(tt.val, tt.tag)
+		if tt.valid {
+			assert.NoError(t, err, "%s %q", tt.tag, tt.val)
+		} else {
+			assert.Error(t, err, "%s %q", tt.tag, tt.val)
+		}
+	}
+
+	policy := WithRequestIDPolicy(IDPolicy{MinLen: 3, MaxLen: 12, Charset: "/"})
+	assert.NoError(t, Var("req/8f2a9c1d", "requestid", policy))
+	assert.NoError(t, Var("abc", "requestid", policy))
+	assert.Error(t, Var("req/8f2a9c1d0", "requestid", policy))
+	assert.Error(t, Var("k7Qx9LmP2vRt", "idempotency_key", WithIdempotencyKeyPolicy(IDPolicy{MinLen: 16})))
+}
+
+func TestEntropy(t *testing.T) {
+	assert.Equal(t, 0.0, entropy(""))
+	assert.Equal(t, 0.0, entropy("aaaa"))
+	assert.Equal(t, 4.0, entropy("abab"))
+	assert.Equal(t, 8.0, entropy("abcd"))
+}
@@ -0,0 +1,16 @@
+package validate
+
+import "context"
+
+// ValidatorIface is the set of entry points of a Validator. Depend on it
+// rather than on *Validator to substitute a test double, such as the one
+// from the validatetest package.
+type ValidatorIface interface {
+	Validate(s any, opts ...Option) error
+	ValidateCtx(ctx context.Context, s any, opts ...Option) error
+	ValidatePartial(s any, selectors ...string) error
+	ValidateExcept(s any, selectors ...string) error
+	Var(val any, tag string, opts ...Option) error
+}
+
+var _ ValidatorIface = (*Validator)(nil)
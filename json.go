@@ -0,0 +1,63 @@
+package validate
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// fieldErrorJSON is the JSON form of a FieldError.
+type fieldErrorJSON struct {
+	Field       string `json:"field"`
+	Path        string `json:"path"`
+	Rule        string `json:"rule"`
+	Param       string `json:"param"`
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	Description string `json:"description,omitempty"`
+	Suggestion  string `json:"suggestion,omitempty"`
+	DocURL      string `json:"doc_url,omitempty"`
+}
+
+// MarshalJSON encodes e as an object with the field, path, rule, param,
+// code and message keys, always present, and the description, suggestion
+// and doc_url keys when set, e.g. {"field":"Name","path":".Name",
+// "rule":"min","param":"3","code":"VAL_MIN",
+// "message":".Name: validation failed for \"min\" tag"}. The value is left
+// out, as it may be sensitive.
+func (e FieldError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fieldErrorJSON{
+		Field:       e.StructField,
+		Path:        e.Path,
+		Rule:        e.Rule,
+		Param:       e.Param,
+		Code:        e.Code,
+		Message:     e.Err.Error(),
+		Description: e.Description,
+		Suggestion:  e.Suggestion,
+		DocURL:      e.DocURL,
+	})
+}
+
+// MarshalJSON encodes v as an array of FieldError objects, empty rather
+// than null when v is nil.
+func (v ValidationErrors) MarshalJSON() ([]byte, error) {
+	if v == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]FieldError(v))
+}
+
+// JSONFieldName returns the name of field in JSON, following the rules of
+// encoding/json: the name of its json tag, or else its Go name.
+func JSONFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return field.Name
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
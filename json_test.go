@@ -0,0 +1,62 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	type S struct {
+		Name string `validate:"min:3" desc:"Display name"`
+		Role string `validate:"in:admin,user"`
+	}
+	var valErrs ValidationErrors
+	assert.True(t, errors.As(Validate(S{Name: "Al", Role: "usr"}, WithSuggestions()), &valErrs))
+	b, err := json.Marshal(valErrs)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"field":"Name","path":".Name","rule":"min","param":"3","code":"VAL_MIN",
+		 "message":".Name: validation failed for \"min\" tag","description":"Display name"},
+		{"field":"Role","path":".Role","rule":"in","param":"admin,user","code":"VAL_IN",
+		 "message":".Role: validation failed for \"in\" tag: did you mean \"user\"?","suggestion":"user"}
+	]`, string(b))
+
+	b, err = json.Marshal(ValidationErrors(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(b))
+
+	b, err = json.Marshal(ValidationErrors{{Err: ErrInvalidValidatorSyntax}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"field":"","path":"","rule":"","param":"","code":"","message":"invalid validator syntax"}]`, string(b))
+}
+
+func TestJSONFieldNames(t *testing.T) {
+	type Address struct {
+		PostalCode string `json:"postal_code" validate:"len:5"`
+	}
+	type User struct {
+		UserName string            `json:"user_name,omitempty" validate:"min:3"`
+		Email    string            `json:",omitempty" validate:"email"`
+		Secret   string            `json:"-" validate:"min:8"`
+		Address  Address           `json:"address"`
+		Tags     map[string]string `json:"tags" validate:"values:min:1"`
+	}
+	user := User{UserName: "al", Email: "x", Secret: "s", Address: Address{PostalCode: "1"}, Tags: map[string]string{"k": ""}}
+	var paths []string
+	var valErrs ValidationErrors
+	assert.ErrorAs(t, Validate(user, WithJSONFieldNames()), &valErrs)
+	for _, fe := range valErrs {
+		paths = append(paths, fe.Path)
+	}
+	assert.Equal(t, []string{".user_name", ".Email", ".Secret", ".address.postal_code", `.tags["k"]`}, paths)
+	assert.Equal(t, "UserName", valErrs[0].StructField)
+
+	upper := func(f reflect.StructField) string { return strings.ToUpper(f.Name) }
+	assert.ErrorAs(t, Validate(user, WithFieldNameFunc(upper), WithFailFast()), &valErrs)
+	assert.Equal(t, ".USERNAME", valErrs[0].Path)
+}
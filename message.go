@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// messages holds the message templates registered with RegisterMessage,
+// keyed by rule.
+var messages sync.Map
+
+// RegisterMessage makes tmpl the message of the failures of rule for every
+// Validator, in place of the generated one. Placeholders are replaced by
+// the failure: {field} by its path without the leading dot, or "value" for
+// values given to Var, {param} by the parameter of the rule, {value} by
+// the failed value and {rule} by the rule name, e.g. "{field} must be at
+// least {param} characters". Messages given by the msg pseudo-rule of a
+// tag take precedence, and translations do not replace either. An empty
+// tmpl restores the generated message.
+func RegisterMessage(rule string, tmpl string) {
+	if tmpl == "" {
+		messages.Delete(rule)
+		return
+	}
+	messages.Store(rule, tmpl)
+}
+
+// customMessage is the error of a failure whose message was set by the msg
+// pseudo-rule of its tag or by RegisterMessage.
+type customMessage string
+
+func (m customMessage) Error() string {
+	return string(m)
+}
+
+// failure returns the error of fe, a failure of rule fe.Rule: its
+// registered message, or else the generated one followed by detail when
+// set, or else by the suggestion of fe, e.g. `did you mean "green"?`.
+func failure(fe FieldError, detail string) error {
+	if tmpl, ok := messages.Load(fe.Rule); ok {
+		return customMessage(strings.NewReplacer(
+			"{field}", fieldName(fe.Path),
+			"{param}", fe.Param,
+			"{value}", fmt.Sprint(fe.Value),
+			"{rule}", fe.Rule,
+		).Replace(tmpl.(string)))
+	}
+	msg := fmt.Sprintf("validation failed for %q tag", fe.Rule)
+	if fe.Path != "" {
+		msg = fe.Path + ": " + msg
+	}
+	if detail == "" && fe.Suggestion != "" {
+		detail = fmt.Sprintf("did you mean %q?", fe.Suggestion)
+	}
+	if detail != "" {
+		msg += ": " + detail
+	}
+	return errors.New(msg)
+}
+
+// fieldName returns the name of the field at path in messages.
+func fieldName(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return strings.TrimPrefix(path, ".")
+}
@@ -0,0 +1,51 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterMessage(t *testing.T) {
+	RegisterMessage("min", "{field} must be at least {param} characters, got {value}")
+	defer RegisterMessage("min", "")
+	RegisterMessage("gtfield", "{field} must come after {param}")
+	defer RegisterMessage("gtfield", "")
+
+	type Booking struct {
+		Name  string `validate:"min:3"`
+		Code  string `validate:"min:6;msg:codes have 6 characters"`
+		Start int
+		End   int      `validate:"gtfield:Start"`
+		Notes []string `validate:"max:2"`
+	}
+	err := Validate(Booking{Name: "Al", Code: "x", Start: 2, End: 1, Notes: []string{"abc"}})
+	var valErrs ValidationErrors
+	assert.ErrorAs(t, err, &valErrs)
+	var msgs []string
+	for _, fe := range valErrs {
+		msgs = append(msgs, fe.Error())
+	}
+	assert.Equal(t, []string{
+		"Name must be at least 3 characters, got Al",
+		"codes have 6 characters",
+		"End must come after Start",
+		`.Notes[0]: validation failed for "max" tag`,
+	}, msgs)
+	assert.EqualError(t, Var(1, "min:2"), "value must be at least 2 characters, got 1")
+
+	// Translations replace neither registered messages nor msg overrides.
+	msgs = nil
+	for _, fe := range valErrs.Translate("en") {
+		msgs = append(msgs, fe.Error())
+	}
+	assert.Equal(t, []string{
+		"Name must be at least 3 characters, got Al",
+		"codes have 6 characters",
+		"End must come after Start",
+		"Notes[0] must be at most 2",
+	}, msgs)
+
+	RegisterMessage("min", "")
+	assert.EqualError(t, Var(1, "min:2"), `validation failed for "min" tag`)
+}
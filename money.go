@@ -0,0 +1,103 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// currencyExponents maps the ISO 4217 currency codes in use to their
+// minor unit exponent: the number of decimals of their amounts.
+var currencyExponents = func() map[string]int {
+	byExponent := []string{
+		0: "BIF CLP DJF GNF ISK JPY KMF KRW PYG RWF UGX UYI VND VUV XAF XOF XPF",
+		2: "AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BOV " +
+			"BRL BSD BTN BWP BYN BZD CAD CDF CHE CHF CHW CNY COP COU CRC CUP CVE CZK " +
+			"DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GTQ GYD HKD HNL " +
+			"HTG HUF IDR ILS INR IRR JMD KES KGS KHR KPW KYD KZT LAK LBP LKR LRD LSL " +
+			"MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO " +
+			"NOK NPR NZD PAB PEN PGK PHP PKR PLN QAR RON RSD RUB SAR SBD SCR SDG SEK " +
+			"SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TOP TRY TTD TWD TZS " +
+			"UAH USD USN UYU UZS VED VES WST XCD XCG YER ZAR ZMW ZWG",
+		3: "BHD IQD JOD KWD LYD OMR TND",
+		4: "CLF UYW",
+	}
+	res := make(map[string]int)
+	for exp, codes := range byExponent {
+		for _, code := range strings.Fields(codes) {
+			res[code] = exp
+		}
+	}
+	return res
+}()
+
+// money is the compiled parameter of the money rule, e.g. "money:USD" or
+// "money:USD,6". Integers are amounts in units of 10^-scale of the
+// currency, its minor unit by default: with a larger scale they must be
+// multiples of the minor unit. Floats and decimal strings are amounts in
+// the currency and may not have more decimals than its minor unit.
+type money struct {
+	exponent int
+	// step is the minor unit in units of 10^-scale.
+	step uint64
+}
+
+func compileMoney(p param, owner reflect.Type) (any, error) {
+	elems := p.list()
+	if len(elems) > 2 {
+		return nil, fmt.Errorf("%w: money: too many parameters in %q", ErrInvalidValidatorSyntax, p.val)
+	}
+	exp, ok := currencyExponents[elems[0]]
+	if !ok {
+		return nil, fmt.Errorf("%w: money: unknown currency %q", ErrInvalidValidatorSyntax, elems[0])
+	}
+	m := &money{exponent: exp, step: 1}
+	if len(elems) == 2 {
+		scale, err := strconv.Atoi(elems[1])
+		if err != nil || scale < 0 || scale > 18 {
+			return nil, fmt.Errorf("%w: money: invalid scale %q", ErrInvalidValidatorSyntax, elems[1])
+		}
+		for i := exp; i < scale; i++ {
+			m.step *= 10
+		}
+	}
+	return m, nil
+}
+
+// decimals reports whether the decimal number s has at most the decimals
+// of the currency.
+func (m *money) decimals(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || len(frac) > m.exponent {
+		return false
+	}
+	for _, part := range []string{whole, frac} {
+		for i := 0; i < len(part); i++ {
+			if part[i] < '0' || part[i] > '9' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var moneyRule = rule{
+	assertInt: func(val int64, p param) (bool, error) {
+		if val < 0 {
+			val = -val
+		}
+		return uint64(val)%p.compiled.(*money).step == 0, nil
+	},
+	assertUint: func(val uint64, p param) (bool, error) {
+		return val%p.compiled.(*money).step == 0, nil
+	},
+	assertFloat: func(val float64, p param) (bool, error) {
+		return p.compiled.(*money).decimals(strconv.FormatFloat(val, 'f', -1, 64)), nil
+	},
+	assertStr: func(val string, p param) (bool, error) {
+		return p.compiled.(*money).decimals(val), nil
+	},
+	compile: compileMoney,
+}
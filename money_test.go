@@ -0,0 +1,44 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		tag   string
+		val   any
+		valid bool
+	}{
+		{"money:USD", 1999, true},
+		{"money:USD", 19.99, true},
+		{"money:USD", 19.999, false},
+		{"money:JPY", 500.0, true},
+		{"money:JPY", 500.5, false},
+		{"money:KWD", "12.345", true},
+		{"money:KWD", "12.3456", false},
+		{"money:EUR", "-0.50", true},
+		{"money:EUR", "1e3", false},
+		{"money:EUR", ".5", false},
+		{"money:USD,6", int64(19_990_000), true},
+		{"money:USD,6", int64(-19_990_000), true},
+		{"money:USD,6", int64(19_999_999), false},
+		{"money:JPY,2", uint(1200), true},
+		{"money:JPY,2", uint(1250), false},
+		{"money:JPY,0", uint(1250), true},
+	}
+	for _, tt := range tests {
+		err := Var(tt.val, tt.tag)
+		if tt.valid {
+			assert.NoError(t, err, "%s %v", tt.tag, tt.val)
+		} else {
+			assert.EqualError(t, err, `validation failed for "money" tag`, "%s %v", tt.tag, tt.val)
+		}
+	}
+
+	for _, tag := range []string{"money:XXX", "money:usd", "money:USD,x", "money:USD,2,3"} {
+		assert.ErrorContains(t, Var(1, tag), "invalid validator syntax: money:", tag)
+	}
+}
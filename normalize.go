@@ -0,0 +1,174 @@
+package validate
+
+import (
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// WithNormalize makes modifiers like trim, and rules parsing their value
+// like email, write the normalized value back to the validated field.
+// Only fields reachable through a pointer can be written: pass a pointer to
+// the struct to Validate to normalize all of them.
+func WithNormalize() Option {
+	return func(o *options) {
+		o.normalize = true
+	}
+}
+
+// normalizeEmail returns the canonical form of an email address, with
+// its domain lowercased.
+func normalizeEmail(val string) (string, bool) {
+	addr, err := mail.ParseAddress(val)
+	if err != nil || addr.Name != "" || addr.Address != val {
+		return "", false
+	}
+	at := strings.LastIndexByte(val, '@')
+	return val[:at] + strings.ToLower(val[at:]), true
+}
+
+// normalizeURL returns the canonical form of an absolute URL, with its
+// scheme and host lowercased.
+func normalizeURL(val string) (string, bool) {
+	u, err := url.Parse(val)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", false
+	}
+	u.Host = strings.ToLower(u.Host)
+	return u.String(), true
+}
+
+// normalizePhone returns the E.164 form of an international phone number
+// starting with "+" or "00", e.g. "+1 (555) 010-9999" is "+15550109999".
+// Spaces, dots, dashes and parentheses are ignored.
+func normalizePhone(val string) (string, bool) {
+	rest, ok := strings.CutPrefix(val, "+")
+	if !ok {
+		if rest, ok = strings.CutPrefix(val, "00"); !ok {
+			return "", false
+		}
+	}
+	digits := make([]byte, 1, 16)
+	digits[0] = '+'
+	for i := 0; i < len(rest); i++ {
+		switch c := rest[i]; {
+		case '0' <= c && c <= '9':
+			digits = append(digits, c)
+		case c == ' ' || c == '.' || c == '-' || c == '(' || c == ')':
+		default:
+			return "", false
+		}
+	}
+	if n := len(digits) - 1; n < 8 || n > 15 || digits[1] == '0' {
+		return "", false
+	}
+	return string(digits), true
+}
+
+// normalizingRule returns a rule checking that a string parses with fn,
+// whose result is the normalized value.
+func normalizingRule(fn func(string) (string, bool)) rule {
+	return rule{
+		assertStr: func(val string, p param) (bool, error) {
+			_, ok := fn(val)
+			return ok, nil
+		},
+		normalize: fn,
+		noParam:   true,
+	}
+}
+
+// store writes v to target, the validated value, when normalizing and
+// target can be set.
+func (c *validation) store(target, v reflect.Value) {
+	if c.opts.normalize && target.CanSet() && target.Kind() == reflect.String {
+		target.SetString(v.String())
+	}
+}
+
+// ValidateAndNormalize validates a copy of val with the package default
+// Validator, see ValidateAndNormalizeWith.
+func ValidateAndNormalize[T any](val T, opts ...Option) (T, error) {
+	return ValidateAndNormalizeWith(defaultValidator, val, opts...)
+}
+
+// ValidateAndNormalizeWith validates a deep copy of val with v and
+// WithNormalize, and returns the copy with its fields normalized, leaving
+// val and the values it points to untouched. The copy is returned even
+// when validation fails. Unexported fields are copied shallowly, as they
+// are never normalized.
+func ValidateAndNormalizeWith[T any](v ValidatorIface, val T, opts ...Option) (T, error) {
+	cp := reflect.New(reflect.TypeOf(&val).Elem())
+	cp.Elem().Set(deepCopy(reflect.ValueOf(&val).Elem(), make(map[visit]reflect.Value)))
+	target := cp
+	if cp.Elem().Kind() == reflect.Pointer {
+		target = cp.Elem()
+	}
+	err := v.Validate(target.Interface(), append(opts[:len(opts):len(opts)], WithNormalize())...)
+	return cp.Elem().Interface().(T), err
+}
+
+// deepCopy returns a copy of v sharing no pointer, slice or map with it,
+// but through unexported fields and map keys. copies maps the pointers
+// already copied, with their type since a struct and its first field share
+// their address, to their copy, so that shared and cyclic pointers are
+// preserved.
+func deepCopy(v reflect.Value, copies map[visit]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := visit{v.Pointer(), v.Type()}
+		if cp, ok := copies[key]; ok {
+			return cp
+		}
+		cp := reflect.New(v.Type().Elem())
+		copies[key] = cp
+		cp.Elem().Set(deepCopy(v.Elem(), copies))
+		return cp
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(deepCopy(v.Elem(), copies))
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i), copies))
+		}
+		return cp
+	case reflect.Array:
+		cp := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i), copies))
+		}
+		return cp
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), deepCopy(iter.Value(), copies))
+		}
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(deepCopy(v.Field(i), copies))
+			}
+		}
+		return cp
+	}
+	return v
+}
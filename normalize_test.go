@@ -0,0 +1,118 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsingRules(t *testing.T) {
+	tests := []struct {
+		tag, val string
+		valid    bool
+	}{
+		{"email", "bob@example.com", true},
+		{"email", "Bob <bob@example.com>", false},
+		{"email", "bob@", false},
+		{"email", "", false},
+		{"url", "https://example.com/a?b=c", true},
+		{"url", "example.com", false},
+		{"url", "/relative", false},
+		{"phone", "+1 (555) 010-9999", true},
+		{"phone", "0044 20 7946 0958", true},
+		{"phone", "555-0109", false},
+		{"phone", "+0 555 010 9999", false},
+		{"phone", "+1 555 010 9999 123456", false},
+		{"phone", "+1 555 CALL NOW", false},
+	}
+	for _, tt := range tests {
+		err := Var(tt.val, tt.tag)
+		if tt.valid {
+			assert.NoError(t, err, "%s %q", tt.tag, tt.val)
+		} else {
+			assert.Error(t, err, "%s %q", tt.tag, tt.val)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	type contact struct {
+		Email string  `validate:"trim|email"`
+		Site  string  `validate:"url"`
+		Phone *string `validate:"phone;len:12"`
+		Nick  string  `validate:"trim|lower|min:3"`
+	}
+	phone := "+1 (555) 010-9999"
+	c := contact{Email: " Bob@Example.COM ", Site: "HTTPS://Example.com/Path", Phone: &phone, Nick: " BOB "}
+
+	// Without WithNormalize, nothing is written back.
+	assert.NoError(t, Validate(&c))
+	assert.Equal(t, " Bob@Example.COM ", c.Email)
+
+	assert.NoError(t, Validate(&c, WithNormalize()))
+	assert.Equal(t, contact{Email: "Bob@example.com", Site: "https://example.com/Path", Phone: &phone, Nick: "bob"}, c)
+	assert.Equal(t, "+15550109999", phone)
+
+	// Fields of a struct passed by value cannot be written.
+	c2 := contact{Email: "a@B.io", Site: "http://x.io", Nick: "Abc"}
+	assert.NoError(t, Validate(c2, WithNormalize()))
+	assert.Equal(t, "a@B.io", c2.Email)
+
+	// Failing values are left alone.
+	c3 := contact{Email: " not an email ", Site: "http://x.io", Nick: "ABC"}
+	assert.Error(t, Validate(&c3, WithNormalize()))
+	assert.Equal(t, "not an email", c3.Email)
+}
+
+func TestValidateAndNormalize(t *testing.T) {
+	type contact struct {
+		Email string  `validate:"trim|email"`
+		Phone *string `validate:"phone"`
+	}
+	type book struct {
+		Owner    contact
+		Contacts []contact
+		ByName   map[string]*contact
+		Self     *book
+	}
+	phone := "+1 (555) 010-9999"
+	shared := &contact{Email: " c@C.io "}
+	b := book{
+		Owner:    contact{Email: " Bob@Example.COM ", Phone: &phone},
+		Contacts: []contact{{Email: "A@B.IO"}},
+		ByName:   map[string]*contact{"c": shared},
+	}
+	b.Self = &b
+
+	got, err := ValidateAndNormalize(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bob@example.com", got.Owner.Email)
+	assert.Equal(t, "+15550109999", *got.Owner.Phone)
+	assert.Equal(t, "A@b.io", got.Contacts[0].Email)
+	assert.Equal(t, "c@c.io", got.ByName["c"].Email)
+	assert.Same(t, got.Self, got.Self.Self)
+
+	assert.Equal(t, " Bob@Example.COM ", b.Owner.Email)
+	assert.Equal(t, "+1 (555) 010-9999", phone)
+	assert.Equal(t, "A@B.IO", b.Contacts[0].Email)
+	assert.Equal(t, " c@C.io ", shared.Email)
+
+	p, err := ValidateAndNormalize(&contact{Email: " x "})
+	assert.Error(t, err)
+	assert.Equal(t, "x", p.Email)
+
+	// A struct and its first field share their address.
+	type inner struct {
+		X int
+	}
+	type outer struct {
+		A *inner
+		B *int
+	}
+	in := &inner{X: 1}
+	o, err := ValidateAndNormalize(&outer{A: in, B: &in.X})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, o.A.X)
+	assert.Equal(t, 1, *o.B)
+	assert.NotSame(t, in, o.A)
+}
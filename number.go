@@ -0,0 +1,139 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+// jsonNumber returns the value of json.Number v as an integer when both v
+// and the parameter p are integers, so that large integers compare
+// exactly: an int64, a uint64 above its range, or else a big.Int. It is a
+// float64 otherwise. The empty number is zero.
+func jsonNumber(v reflect.Value, p param) (reflect.Value, error) {
+	s := v.String()
+	if s == "" {
+		s = "0"
+	}
+	if isIntParam(p) {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return reflect.ValueOf(n), nil
+		}
+		if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return reflect.ValueOf(n), nil
+		}
+		if n, ok := new(big.Int).SetString(s, 10); ok {
+			return reflect.ValueOf(n).Elem(), nil
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return v, fmt.Errorf("invalid json.Number %q", s)
+	}
+	return reflect.ValueOf(f), nil
+}
+
+// isIntParam reports whether all elements of p are integers.
+func isIntParam(p param) bool {
+	if p.val == "" {
+		return true
+	}
+	for _, elem := range p.values() {
+		if !elem.integer {
+			return false
+		}
+	}
+	return true
+}
+
+// NumberLike is implemented by arbitrary-precision number types, like
+// decimals, to be compared by min, max, eq and in without loss. Cmp
+// compares the number with the rule parameter s, returning -1, 0 or 1 like
+// big.Int.Cmp, or an error when s is not a number. *big.Int, *big.Float
+// and *big.Rat are supported out of the box.
+type NumberLike interface {
+	Cmp(s string) (int, error)
+}
+
+var (
+	numberLikeType = reflect.TypeOf((*NumberLike)(nil)).Elem()
+	bigIntType     = reflect.TypeOf(big.Int{})
+	bigFloatType   = reflect.TypeOf(big.Float{})
+	bigRatType     = reflect.TypeOf(big.Rat{})
+)
+
+// isNumber reports whether t is compared as a number through numberCmp.
+func isNumber(t reflect.Type) bool {
+	switch t {
+	case bigIntType, bigFloatType, bigRatType:
+		return true
+	}
+	return t.Implements(numberLikeType) || reflect.PointerTo(t).Implements(numberLikeType)
+}
+
+// numberCmp returns a function comparing v with a parameter when v is an
+// arbitrary-precision number.
+func numberCmp(v reflect.Value) (func(s string) (int, error), bool) {
+	if !v.CanInterface() || !isNumber(v.Type()) {
+		return nil, false
+	}
+	if v.CanAddr() {
+		v = v.Addr()
+	} else {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p
+	}
+	switch x := v.Interface().(type) {
+	case *big.Int:
+		return func(s string) (int, error) {
+			return cmpRat(new(big.Rat).SetInt(x), s)
+		}, true
+	case *big.Rat:
+		return func(s string) (int, error) {
+			return cmpRat(x, s)
+		}, true
+	case *big.Float:
+		return func(s string) (int, error) {
+			y, ok := new(big.Float).SetPrec(256).SetString(s)
+			if !ok {
+				return 0, ErrInvalidValidatorSyntax
+			}
+			return x.Cmp(y), nil
+		}, true
+	case NumberLike:
+		return x.Cmp, true
+	}
+	return nil, false
+}
+
+func cmpRat(x *big.Rat, s string) (int, error) {
+	y, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, ErrInvalidValidatorSyntax
+	}
+	return x.Cmp(y), nil
+}
+
+// finiteRule requires a float neither NaN nor infinite, as JSON decoders
+// and protobuf may produce. Comparison rules like min and max fail on NaN,
+// but infinities compare like very large numbers.
+var finiteRule = rule{
+	assertFloat: func(val float64, p param) (bool, error) {
+		return !math.IsNaN(val) && !math.IsInf(val, 0), nil
+	},
+	noParam: true,
+}
+
+// noNaNRule requires a float other than NaN, infinities included.
+var noNaNRule = rule{
+	assertFloat: func(val float64, p param) (bool, error) {
+		return !math.IsNaN(val), nil
+	},
+	noParam: true,
+}
@@ -0,0 +1,165 @@
+package validate
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONNumber(t *testing.T) {
+	type item struct {
+		Qty   json.Number `validate:"required;min:1;max:100"`
+		Price json.Number `validate:"min:0.01"`
+		Size  json.Number `validate:"in:1,2,3.5"`
+	}
+	decode := func(s string) (it item) {
+		d := json.NewDecoder(strings.NewReader(s))
+		d.UseNumber()
+		assert.NoError(t, d.Decode(&it))
+		return it
+	}
+	assert.NoError(t, Validate(decode(`{"Qty": 12, "Price": 9.99, "Size": 3.5}`)))
+
+	err := Validate(decode(`{"Qty": 1000, "Price": 0.001, "Size": 3}`))
+	assert.EqualError(t, err, `.Qty: validation failed for "max" tag`+
+		`; .Price: validation failed for "min" tag`+
+		`; .Size: validation failed for "in" tag`)
+
+	err = Validate(decode(`{"Qty": 1e400, "Size": 1}`))
+	assert.EqualError(t, err, `invalid json.Number "1e400"`)
+
+	err = Validate(item{Size: "2"})
+	assert.EqualError(t, err, `.Qty: validation failed for "required" tag`+
+		`; .Qty: validation failed for "min" tag`+
+		`; .Price: validation failed for "min" tag`)
+}
+
+// cents is a fixed-point decimal with two fractional digits.
+type cents int64
+
+func (c cents) Cmp(s string) (int, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, ErrInvalidValidatorSyntax
+	}
+	return big.NewRat(int64(c), 100).Cmp(r), nil
+}
+
+func TestNumberLike(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	type account struct {
+		Balance *big.Int   `validate:"required;min:0;max:1e30"`
+		Rate    *big.Rat   `validate:"min:0;max:1/10"`
+		Ratio   big.Float  `validate:"in:0.5,0.25"`
+		Fee     cents      `validate:"min:0.05;max:9.99"`
+		Limit   *big.Int   `validate:"eq:123456789012345678901234567890"`
+		Rates   []*big.Rat `validate:"max:1"`
+	}
+	valid := account{
+		Balance: huge,
+		Rate:    big.NewRat(1, 20),
+		Ratio:   *big.NewFloat(0.25),
+		Fee:     5,
+		Limit:   huge,
+		Rates:   []*big.Rat{big.NewRat(1, 2)},
+	}
+	assert.NoError(t, Validate(valid))
+	assert.NoError(t, Validate(&valid))
+
+	err := Validate(account{
+		Balance: new(big.Int).Neg(huge),
+		Rate:    big.NewRat(1, 9),
+		Ratio:   *big.NewFloat(0.3),
+		Fee:     1000,
+		Limit:   big.NewInt(1),
+		Rates:   []*big.Rat{big.NewRat(3, 2)},
+	})
+	assert.EqualError(t, err, `.Balance: validation failed for "min" tag`+
+		`; .Rate: validation failed for "max" tag`+
+		`; .Ratio: validation failed for "in" tag`+
+		`; .Fee: validation failed for "max" tag`+
+		`; .Limit: validation failed for "eq" tag`+
+		`; .Rates[0]: validation failed for "max" tag`)
+
+	err = Validate(struct {
+		N *big.Int `validate:"required"`
+		Z big.Int  `validate:"required"`
+	}{})
+	assert.EqualError(t, err, `.N: validation failed for "required" tag`+
+		`; .Z: validation failed for "required" tag`)
+
+	assert.Equal(t, ValidationErrors{{Err: ErrInvalidValidatorSyntax}}, Var(huge, "min:abc"))
+	assert.EqualError(t, Var(huge, "len:3"), "unsupported type big.Int")
+}
+
+func TestIntegerOverflow(t *testing.T) {
+	const huge = "100000000000000000000" // 1e20, beyond int64 and uint64
+	for _, tt := range []struct {
+		val     any
+		tag     string
+		opts    []Option
+		wantErr error
+	}{
+		{int64(5), "max:" + huge, nil, ErrNumericOverflow},
+		{int64(5), "max:" + huge, []Option{WithOverflowPolicy(OverflowCompare)}, nil},
+		{int64(5), "min:" + huge, []Option{WithOverflowPolicy(OverflowCompare)}, RuleFailed("min")},
+		{int64(-5), "min:-" + huge, []Option{WithOverflowPolicy(OverflowCompare)}, nil},
+		{int8(5), "in:1," + huge, []Option{WithOverflowPolicy(OverflowCompare)}, RuleFailed("in")},
+		{uint64(5), "max:" + huge, nil, ErrNumericOverflow},
+		{uint64(5), "eq:" + huge, []Option{WithOverflowPolicy(OverflowCompare)}, RuleFailed("eq")},
+		{uint64(5), "min:-" + huge, nil, nil},
+		{uint64(1<<63 + 1), "min:9223372036854775808", nil, nil},
+		{uint64(1 << 63), "max:9223372036854775807", nil, RuleFailed("max")},
+		{int64(5), "max:ten", []Option{WithOverflowPolicy(OverflowCompare)}, ErrInvalidValidatorSyntax},
+		{json.Number("18446744073709551615"), "max:18446744073709551614", nil, RuleFailed("max")},
+		{json.Number("18446744073709551615"), "min:18446744073709551615", nil, nil},
+		{json.Number(huge + "1"), "min:" + huge, nil, nil},
+		{json.Number(huge), "max:9223372036854775807", nil, RuleFailed("max")},
+	} {
+		err := Var(tt.val, tt.tag, tt.opts...)
+		if tt.wantErr == nil {
+			assert.NoError(t, err, "%v %s", tt.val, tt.tag)
+		} else {
+			assert.ErrorIs(t, err, tt.wantErr, "%v %s", tt.val, tt.tag)
+		}
+	}
+	assert.ErrorIs(t, Var(1, "max:"+huge), ErrInvalidValidatorSyntax)
+	assert.EqualError(t, Var(1, "max:"+huge), "invalid validator syntax: numeric overflow: parameter "+huge+" is out of the range of int64")
+}
+
+func TestNonFiniteFloats(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	for _, tt := range []struct {
+		val any
+		tag string
+		ok  bool
+	}{
+		{1.5, "finite", true},
+		{float32(-2), "finite", true},
+		{nan, "finite", false},
+		{inf, "finite", false},
+		{-inf, "finite", false},
+		{float32(nan), "finite", false},
+		{inf, "nonan", true},
+		{nan, "nonan", false},
+		{nan, "min:0", false},
+		{nan, "max:0", false},
+		{nan, "eq:0", false},
+		{nan, "in:0,1", false},
+		{inf, "min:0", true},
+		{inf, "max:1e308", false},
+		{-inf, "min:-1e308", false},
+		{json.Number("NaN"), "finite", false},
+	} {
+		err := Var(tt.val, tt.tag)
+		if tt.ok {
+			assert.NoError(t, err, "%v %s", tt.val, tt.tag)
+		} else {
+			assert.Error(t, err, "%v %s", tt.val, tt.tag)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package validate
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// UnwrapFunc extracts the value held by an optional type. It returns
+// ok == false for values it does not handle and present == false for empty
+// optionals. Rules of an empty optional are skipped, except required which
+// fails; required passes on any present optional, even holding a zero value.
+// See WithNilPolicy to make the other rules fail instead.
+type UnwrapFunc func(v any) (inner any, present, ok bool)
+
+// WithUnwrapFunc registers fn to unwrap custom optional types. Functions
+// are tried in registration order, before the built-in unwrapping of:
+//   - types with IsPresent() bool and OrEmpty() T methods, like mo.Option;
+//   - types with a Valid bool field and a ValueOrZero() T method, like the
+//     guregu/null types;
+//   - the sql.Null* types of database/sql;
+//   - types implementing driver.Valuer, through their Value method.
+func WithUnwrapFunc(fn UnwrapFunc) Option {
+	return func(o *options) {
+		o.unwrappers = append(o.unwrappers, fn)
+	}
+}
+
+// unwrap returns the value held by v when v is an optional, or the zero
+// Value when the optional is empty. ok is false when v is not an optional.
+func (c *validation) unwrap(v reflect.Value) (inner reflect.Value, ok bool, err error) {
+	if !v.IsValid() || !v.CanInterface() {
+		return v, false, nil
+	}
+	if len(c.opts.unwrappers) > 0 {
+		vi := v.Interface()
+		for _, fn := range c.opts.unwrappers {
+			if inner, present, ok := fn(vi); ok {
+				if !present {
+					return reflect.Value{}, true, nil
+				}
+				return reflect.ValueOf(inner), true, nil
+			}
+		}
+	}
+	if v.Kind() == reflect.Struct {
+		if opt := optionalOf(v.Type()); opt != nil {
+			if !opt.present(v) {
+				return reflect.Value{}, true, nil
+			}
+			return opt.value(v), true, nil
+		}
+	}
+	return valuerValue(v)
+}
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// valuerValue unwraps values implementing driver.Valuer, like the decimal
+// or ID types of ORMs, into the primitive returned by their Value method.
+// A nil primitive is an empty optional, and []byte is read as a string.
+func valuerValue(v reflect.Value) (inner reflect.Value, ok bool, err error) {
+	var valuer driver.Valuer
+	switch {
+	case v.Type() == timeType:
+		return v, false, nil
+	case v.Type().Implements(valuerType):
+		valuer = v.Interface().(driver.Valuer)
+	case v.CanAddr() && reflect.PointerTo(v.Type()).Implements(valuerType):
+		valuer = v.Addr().Interface().(driver.Valuer)
+	default:
+		return v, false, nil
+	}
+	val, err := valuer.Value()
+	if err != nil {
+		return v, false, err
+	}
+	switch val := val.(type) {
+	case nil:
+		return reflect.Value{}, true, nil
+	case []byte:
+		return reflect.ValueOf(string(val)), true, nil
+	}
+	return reflect.ValueOf(val), true, nil
+}
+
+// optionalType describes how to unwrap a built-in supported optional type.
+type optionalType struct {
+	present func(v reflect.Value) bool
+	value   func(v reflect.Value) reflect.Value
+}
+
+var optionalTypes sync.Map
+
+// optionalOf returns how to unwrap values of type t, or nil when t is not
+// an optional.
+func optionalOf(t reflect.Type) *optionalType {
+	if opt, ok := optionalTypes.Load(t); ok {
+		return opt.(*optionalType)
+	}
+	opt, _ := optionalTypes.LoadOrStore(t, newOptionalType(t))
+	return opt.(*optionalType)
+}
+
+func newOptionalType(t reflect.Type) *optionalType {
+	if isPresent, ok := getter(t, "IsPresent"); ok && isPresent.Type.Out(0).Kind() == reflect.Bool {
+		if orEmpty, ok := getter(t, "OrEmpty"); ok {
+			return &optionalType{
+				present: func(v reflect.Value) bool {
+					return v.Method(isPresent.Index).Call(nil)[0].Bool()
+				},
+				value: method(orEmpty.Index),
+			}
+		}
+	}
+	if valueOrZero, ok := getter(t, "ValueOrZero"); ok && t.Kind() == reflect.Struct {
+		if valid, ok := t.FieldByName("Valid"); ok && valid.Type.Kind() == reflect.Bool {
+			return &optionalType{
+				present: func(v reflect.Value) bool {
+					return v.FieldByIndex(valid.Index).Bool()
+				},
+				value: method(valueOrZero.Index),
+			}
+		}
+	}
+	if t.PkgPath() == "database/sql" && strings.HasPrefix(t.Name(), "Null") && t.NumField() == 2 {
+		// sql.NullString and the like hold their value in their other field.
+		if valid, ok := t.FieldByName("Valid"); ok && valid.Type.Kind() == reflect.Bool {
+			value := 1 - valid.Index[0]
+			return &optionalType{
+				present: func(v reflect.Value) bool {
+					return v.Field(valid.Index[0]).Bool()
+				},
+				value: func(v reflect.Value) reflect.Value {
+					return v.Field(value)
+				},
+			}
+		}
+	}
+	return nil
+}
+
+// method returns a function calling the i-th method of a value.
+func method(i int) func(v reflect.Value) reflect.Value {
+	return func(v reflect.Value) reflect.Value {
+		return v.Method(i).Call(nil)[0]
+	}
+}
+
+// getter returns the exported method name of t if it takes no argument
+// and returns a single value.
+func getter(t reflect.Type, name string) (reflect.Method, bool) {
+	m, ok := t.MethodByName(name)
+	if !ok || m.Type.NumIn() != 1 || m.Type.NumOut() != 1 {
+		return reflect.Method{}, false
+	}
+	return m, true
+}
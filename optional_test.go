@@ -0,0 +1,197 @@
+package validate
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// option mimics mo.Option.
+type option[T any] struct {
+	isPresent bool
+	value     T
+}
+
+func some[T any](v T) option[T] {
+	return option[T]{true, v}
+}
+
+func (o option[T]) IsPresent() bool {
+	return o.isPresent
+}
+
+func (o option[T]) OrEmpty() T {
+	return o.value
+}
+
+// nullString mimics guregu/null.String.
+type nullString struct {
+	sql.NullString
+}
+
+func (s nullString) ValueOrZero() string {
+	return s.String
+}
+
+// box is a custom optional unwrapped with WithUnwrapFunc.
+type box struct {
+	v *int
+}
+
+func TestOptionalTypes(t *testing.T) {
+	type profile struct {
+		Nick  option[string] `validate:"min:3"`
+		Age   option[int]    `validate:"required;min:18"`
+		Tags  option[[]int]  `validate:"in:1,2"`
+		Email nullString     `validate:"max:5"`
+		Phone nullString     `validate:"required"`
+	}
+	tests := []struct {
+		name      string
+		v         profile
+		wantPaths []string
+	}{
+		{
+			name:      "empty optionals",
+			wantPaths: []string{".Age", ".Phone"},
+		},
+		{
+			name: "present zero values pass required",
+			v: profile{
+				Age:   some(18),
+				Phone: nullString{sql.NullString{Valid: true}},
+			},
+		},
+		{
+			name: "present values are validated",
+			v: profile{
+				Nick:  some("ab"),
+				Age:   some(0),
+				Tags:  some([]int{1, 3}),
+				Email: nullString{sql.NullString{String: "abcdef", Valid: true}},
+				Phone: nullString{sql.NullString{Valid: true}},
+			},
+			wantPaths: []string{".Nick", ".Age", ".Tags[1]", ".Email"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.v)
+			if tt.wantPaths == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var paths []string
+			for _, e := range err.(ValidationErrors) {
+				paths = append(paths, e.Path)
+			}
+			assert.Equal(t, tt.wantPaths, paths)
+		})
+	}
+
+	t.Run("unwrap func", func(t *testing.T) {
+		v := New(WithUnwrapFunc(func(v any) (any, bool, bool) {
+			b, ok := v.(box)
+			if !ok {
+				return nil, false, false
+			}
+			if b.v == nil {
+				return nil, false, true
+			}
+			return *b.v, true, true
+		}))
+		type counter struct {
+			N box `validate:"required;max:3"`
+		}
+		n := 5
+		assert.EqualError(t, v.Validate(counter{}), `.N: validation failed for "required" tag`)
+		assert.EqualError(t, v.Validate(counter{box{&n}}), `.N: validation failed for "max" tag`)
+	})
+}
+
+func TestSQLNullTypes(t *testing.T) {
+	type row struct {
+		Name    sql.NullString  `validate:"required;min:3"`
+		Age     sql.NullInt64   `validate:"min:18"`
+		Score   sql.NullFloat64 `validate:"max:1"`
+		Active  sql.NullBool    `validate:"eq:true"`
+		Created sql.NullTime    `validate:"min:2020-01-01T00:00:00Z"`
+		Rank    sql.NullInt16   `validate:"in:1,2,3"`
+	}
+	assert.NoError(t, Validate(row{
+		Name:    sql.NullString{String: "bob", Valid: true},
+		Created: sql.NullTime{Time: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}))
+	// Invalid values hide whatever they hold.
+	assert.NoError(t, Validate(row{
+		Name: sql.NullString{String: "bob", Valid: true},
+		Age:  sql.NullInt64{Int64: 3},
+		Rank: sql.NullInt16{Int16: 7},
+	}))
+
+	err := Validate(row{
+		Name:    sql.NullString{String: "", Valid: true},
+		Age:     sql.NullInt64{Int64: 3, Valid: true},
+		Score:   sql.NullFloat64{Float64: 1.5, Valid: true},
+		Active:  sql.NullBool{Valid: true},
+		Created: sql.NullTime{Valid: true},
+		Rank:    sql.NullInt16{Int16: 7, Valid: true},
+	})
+	assert.EqualError(t, err, `.Name: validation failed for "min" tag`+
+		`; .Age: validation failed for "min" tag`+
+		`; .Score: validation failed for "max" tag`+
+		`; .Active: validation failed for "eq" tag`+
+		`; .Created: validation failed for "min" tag`+
+		`; .Rank: validation failed for "in" tag`)
+
+	err = Validate(row{})
+	assert.EqualError(t, err, `.Name: validation failed for "required" tag`)
+}
+
+// decimal mimics the decimal types of ORMs.
+type decimal struct {
+	units, scale int64
+}
+
+func (d decimal) Value() (driver.Value, error) {
+	if d.scale < 0 {
+		return nil, errors.New("negative scale")
+	}
+	return float64(d.units) / math.Pow10(int(d.scale)), nil
+}
+
+// userID implements driver.Valuer on its pointer.
+type userID string
+
+func (id *userID) Value() (driver.Value, error) {
+	if *id == "" {
+		return nil, nil
+	}
+	return []byte("u-" + *id), nil
+}
+
+func TestDriverValuer(t *testing.T) {
+	type order struct {
+		Total decimal `validate:"min:0.01;max:100"`
+		Owner userID  `validate:"required;len:5"`
+	}
+	assert.NoError(t, Validate(&order{Total: decimal{1999, 2}, Owner: "bob"}))
+
+	err := Validate(&order{Total: decimal{1, 3}, Owner: "alice"})
+	assert.EqualError(t, err, `.Total: validation failed for "min" tag`+
+		`; .Owner: validation failed for "len" tag`)
+
+	// Pointer receivers are only reachable through a pointer.
+	assert.EqualError(t, Validate(order{Total: decimal{1, 0}, Owner: "bob"}), `.Owner: validation failed for "len" tag`)
+
+	err = Validate(&order{Total: decimal{1, 0}})
+	assert.EqualError(t, err, `.Owner: validation failed for "required" tag`)
+
+	err = Validate(order{Total: decimal{1, -1}})
+	assert.EqualError(t, err, ".Total: negative scale")
+}
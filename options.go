@@ -0,0 +1,357 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	defaultMaxDepth = 1000
+	defaultTagKey   = "validate"
+	defaultRuleSep  = ';'
+	defaultKVSep    = ':'
+	defaultListSep  = ','
+)
+
+// syntax describes how tags are written.
+type syntax struct {
+	// tagKeys lists the struct tag keys rules are read from, separated by
+	// spaces, which tag keys cannot contain.
+	tagKeys    string
+	ruleSep    rune
+	kvSep      rune
+	listSep    rune
+	playground bool
+}
+
+type options struct {
+	syntax
+	failFast  bool
+	maxErrors int
+	// keepDuplicates is set by WithDuplicateErrors.
+	keepDuplicates bool
+	groups         []string
+	roles          []string
+	cacheSize      int
+	only           []string
+	except         []string
+	unwrappers     []UnwrapFunc
+	// floatEpsilon is the tolerance of float equality comparisons.
+	floatEpsilon float64
+	nilPolicy    NilPolicy
+	overflow     OverflowPolicy
+	// fieldNames names the fields in error paths, nil for their Go names.
+	fieldNames FieldNameFunc
+	// jsonPointers makes error paths JSON Pointers.
+	jsonPointers bool
+	normalize    bool
+	suggestions  bool
+	quotas       QuotaProvider
+	// idempotencyKeys and requestIDs are the policies of the
+	// idempotency_key and requestid rules, nil for the defaults.
+	idempotencyKeys *IDPolicy
+	requestIDs      *IDPolicy
+	skipUnexported  bool
+	maxDepth        int
+	// promotedPaths makes error paths name the fields of embedded
+	// structs by their promoted names.
+	promotedPaths bool
+	// regions are the regions registered with WithRegion.
+	regions map[string][]LatLng
+	// unitRanges are the unit ranges registered with WithUnitRange.
+	unitRanges map[string]UnitRange
+	recorder   *Recorder
+	// data is the call data set with WithData.
+	data map[string]any
+	// docURLs holds the templates of the documentation URLs of failures
+	// by rule, see WithDocURL.
+	docURLs map[string]string
+	// parallelism is the number of goroutines validating the elements of
+	// slices and arrays, see WithParallelism.
+	parallelism int
+	// trace, set by Trace, receives the steps of the validation.
+	trace *TraceReport
+}
+
+func defaultOptions() options {
+	return options{
+		syntax: syntax{
+			tagKeys: defaultTagKey,
+			ruleSep: defaultRuleSep,
+			kvSep:   defaultKVSep,
+			listSep: defaultListSep,
+		},
+		maxDepth: defaultMaxDepth,
+	}
+}
+
+// Option configures a Validator or a single validation call.
+type Option func(*options)
+
+// WithFailFast stops validation at the first failed rule.
+func WithFailFast() Option {
+	return func(o *options) {
+		o.failFast = true
+	}
+}
+
+// WithMaxErrors stops validation once n rules failed, so that at most n
+// errors are returned. Zero means no limit.
+func WithMaxErrors(n int) Option {
+	return func(o *options) {
+		o.maxErrors = n
+	}
+}
+
+// WithDuplicateErrors keeps the errors repeating the path, rule and
+// parameter of an earlier error, e.g. when tags layered by WithTagKeys or
+// RegisterRules repeat a rule. They are dropped by default.
+func WithDuplicateErrors() Option {
+	return func(o *options) {
+		o.keepDuplicates = true
+	}
+}
+
+// WithGroup activates validation groups. Rules of a tag carrying
+// a "groups:a,b" pseudo-rule are evaluated only when one of the listed
+// groups is active; tags without it are always evaluated.
+func WithGroup(groups ...string) Option {
+	return func(o *options) {
+		o.groups = append(o.groups, groups...)
+	}
+}
+
+// WithRole sets the roles of the caller, e.g. "admin", selecting the
+// rules of tags written for them: with "max:10;role:admin=max:1000", a
+// value is limited to 10, or to 1000 when the admin role is set. The
+// rules of a role replace the rules of the same name without a role;
+// when several roles of the caller have a rule of the same name, each
+// applies.
+func WithRole(roles ...string) Option {
+	return func(o *options) {
+		o.roles = append(o.roles, roles...)
+	}
+}
+
+// WithSeparators changes the separators used in tags: between rules,
+// between a rule and its parameter, and between elements of a set parameter.
+// With WithSeparators('|', '=', ',') a tag reads "in=15:04,16:30|len=5".
+func WithSeparators(rule, keyValue, list rune) Option {
+	return func(o *options) {
+		o.ruleSep, o.kvSep, o.listSep = rule, keyValue, list
+	}
+}
+
+// WithTagKey changes the struct tag key rules are read from, "validate" by
+// default. With WithTagKey("binding") gin-style binding tags are read.
+func WithTagKey(key string) Option {
+	return func(o *options) {
+		o.tagKeys = key
+	}
+}
+
+// WithTagKeys makes rules be read from several struct tag keys and merged,
+// e.g. WithTagKeys("validate_gen", "validate") layers hand-written rules
+// over generated ones. Later keys take precedence: a rule of a later key
+// replaces the rule of the same name of an earlier key, and other rules
+// are appended.
+func WithTagKeys(keys ...string) Option {
+	return func(o *options) {
+		o.tagKeys = strings.Join(keys, " ")
+	}
+}
+
+// FieldNameFunc returns the name of a struct field in error paths. An
+// empty name stands for the Go name of the field.
+type FieldNameFunc func(field reflect.StructField) string
+
+// WithFieldNameFunc makes error paths name fields by fn, e.g. after a tag
+// of their own.
+func WithFieldNameFunc(fn FieldNameFunc) Option {
+	return func(o *options) {
+		o.fieldNames = fn
+	}
+}
+
+// WithXMLFieldNames makes error paths name fields as they appear in XML,
+// according to their xml tags: elements by their name and attributes by
+// their name prefixed with "@", e.g. .item."@id".
+func WithXMLFieldNames() Option {
+	return WithFieldNameFunc(xmlFieldName)
+}
+
+// WithJSONFieldNames makes error paths name fields as they appear in JSON,
+// according to their json tags, e.g. .user_name, see JSONFieldName.
+func WithJSONFieldNames() Option {
+	return WithFieldNameFunc(JSONFieldName)
+}
+
+// WithJSONPointerPaths makes error paths JSON Pointers (RFC 6901), e.g.
+// /items/2/name rather than .items[2].name, see FormatJSONPointer. Along
+// with WithJSONFieldNames, they locate failures in the JSON document.
+// Selectors given to WithPartial and WithExcept keep the default format.
+func WithJSONPointerPaths() Option {
+	return func(o *options) {
+		o.jsonPointers = true
+	}
+}
+
+// WithMaxDepth bounds the nesting of the validated values: validation of
+// values nested deeper than n levels fails with ErrMaxDepthExceeded. It
+// defaults to 1000; zero means no limit. Pointer cycles are detected
+// regardless: a value is not validated again while it is being validated.
+func WithMaxDepth(n int) Option {
+	return func(o *options) {
+		o.maxDepth = n
+	}
+}
+
+// WithSkipUnexported makes unexported fields with rules be skipped, rather
+// than failing the whole validation with ErrValidateForUnexportedFields.
+func WithSkipUnexported() Option {
+	return func(o *options) {
+		o.skipUnexported = true
+	}
+}
+
+// WithPromotedPaths makes error paths name the fields of embedded structs
+// by their promoted names, e.g. .City rather than .Address.City.
+func WithPromotedPaths() Option {
+	return func(o *options) {
+		o.promotedPaths = true
+	}
+}
+
+// WithPlaygroundSyntax makes tags be read in the go-playground/validator
+// dialect, e.g. "required,min=3,max=20". See parsePlaygroundTag for the
+// supported rule names. As with go-playground/validator, len, min, max, gt
+// and lt count the runes of strings rather than their bytes. Unlike it,
+// tags of slices and arrays apply to their elements, not to their length.
+func WithPlaygroundSyntax() Option {
+	return func(o *options) {
+		o.playground = true
+	}
+}
+
+// WithCacheSize bounds the number of struct plans and tags given to Var
+// cached by a Validator, evicting the least recently used ones. Zero
+// means unbounded.
+// It only has effect when passed to New.
+func WithCacheSize(n int) Option {
+	return func(o *options) {
+		o.cacheSize = n
+	}
+}
+
+// WithFloatEpsilon sets the tolerance used when comparing floats for
+// equality, e.g. by the in rule. It defaults to zero: exact comparison.
+func WithFloatEpsilon(eps float64) Option {
+	return func(o *options) {
+		o.floatEpsilon = eps
+	}
+}
+
+// NilPolicy tells how rules treat absent values: nil pointers and empty
+// optionals. Whatever the policy, required fails on absent values and
+// omitempty skips the rules following it.
+type NilPolicy int
+
+const (
+	// NilSkip skips the rules of absent values. It is the default.
+	NilSkip NilPolicy = iota
+	// NilFail makes every rule fail on absent values.
+	NilFail
+)
+
+// WithNilPolicy sets how rules treat nil pointers and empty optionals.
+func WithNilPolicy(p NilPolicy) Option {
+	return func(o *options) {
+		o.nilPolicy = p
+	}
+}
+
+// OverflowPolicy tells how numeric rules treat integer parameters out of
+// the range of the values they are compared with: int64 for signed
+// integers and uint64 for unsigned ones.
+type OverflowPolicy int
+
+const (
+	// OverflowError makes such rules fail with an error matching
+	// ErrNumericOverflow. It is the default.
+	OverflowError OverflowPolicy = iota
+	// OverflowCompare compares such parameters exactly: a parameter above
+	// the range is greater than every value, one below is less.
+	OverflowCompare
+)
+
+// WithOverflowPolicy sets how numeric rules treat integer parameters out
+// of the range of the values they are compared with, e.g. max:1e20 on an
+// int64 field written as 100000000000000000000.
+func WithOverflowPolicy(p OverflowPolicy) Option {
+	return func(o *options) {
+		o.overflow = p
+	}
+}
+
+// with returns a copy of o with opts applied on top.
+func (o options) with(opts []Option) options {
+	if len(opts) == 0 {
+		// Options are applied to o through a pointer, which would make
+		// it escape.
+		return o
+	}
+	return o.apply(opts)
+}
+
+func (o options) apply(opts []Option) options {
+	o.groups = append([]string(nil), o.groups...)
+	o.roles = append([]string(nil), o.roles...)
+	o.only = append([]string(nil), o.only...)
+	o.except = append([]string(nil), o.except...)
+	o.unwrappers = append([]UnwrapFunc(nil), o.unwrappers...)
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// applies reports whether tr, one of tagRules, applies for the roles of
+// the call: the rules of a role apply when it is set and replace the rules
+// of the same name without a role.
+func (o *options) applies(tagRules []tagRule, tr tagRule) bool {
+	if tr.role != "" {
+		return o.hasRole(tr.role)
+	}
+	for _, other := range tagRules {
+		if other.role != "" && other.key == tr.key && o.hasRole(other.role) {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *options) hasRole(role string) bool {
+	for _, r := range o.roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *options) inGroups(tagRules []tagRule) bool {
+	for _, tr := range tagRules {
+		if tr.key != groupsTag {
+			continue
+		}
+		for _, group := range tr.param.list() {
+			for _, active := range o.groups {
+				if group == active {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// WithParallelism makes the elements of slices and arrays be validated by
+// up to n goroutines, each validating a range of elements. Errors are
+// merged by index, so they are the same as without parallelism, and so
+// are the errors returned with WithFailFast and WithMaxErrors, though
+// more elements may be evaluated. Only the outermost slices of a value
+// are split, and not when tracing with Trace. Values and providers given
+// to rules, like QuotaProvider, must be safe for concurrent use. n below
+// 2 disables parallelism.
+func WithParallelism(n int) Option {
+	return func(o *options) {
+		o.parallelism = n
+	}
+}
+
+// elemsResult is the outcome of validating a range of elements.
+type elemsResult struct {
+	errs, warnings ValidationErrors
+	outcomes       map[string]Outcome
+	err            error
+}
+
+// validateElemsParallel validates the elements of slice or array vVal
+// like validateImpl, splitting them into ranges validated concurrently.
+func (c *validation) validateElemsParallel(vVal reflect.Value, vTags []fieldTag, callstack string) (ValidationErrors, error) {
+	n := vVal.Len()
+	workers := c.opts.parallelism
+	if workers > n {
+		workers = n
+	}
+	size := (n + workers - 1) / workers
+	results := make([]elemsResult, workers)
+	var wg sync.WaitGroup
+	for w := range results {
+		lo, hi := w*size, (w+1)*size
+		if hi > n {
+			hi = n
+		}
+		sub := c.fork()
+		wg.Add(1)
+		go func(res *elemsResult) {
+			defer wg.Done()
+			for i := lo; i < hi; i++ {
+				newValErrs, err := sub.validateImpl(vVal.Index(i), vTags, callstack+fmt.Sprintf("[%d]", i), nil)
+				if err != nil {
+					res.err = err
+					break
+				}
+				res.errs = append(res.errs, newValErrs...)
+				if sub.full() {
+					break
+				}
+			}
+			res.warnings, res.outcomes = sub.warnings, sub.outcomes
+		}(&results[w])
+	}
+	wg.Wait()
+	// The failures of each range were found as if no range came before,
+	// so they are counted again to stop where validating in order would.
+	var valErrs ValidationErrors
+	for _, res := range results {
+		c.warnings = append(c.warnings, res.warnings...)
+		for path, o := range res.outcomes {
+			c.record(path, o)
+		}
+		for _, fe := range res.errs {
+			valErrs = append(valErrs, fe)
+			if c.errCount++; c.full() {
+				return valErrs, nil
+			}
+		}
+		if res.err != nil {
+			return nil, res.err
+		}
+	}
+	return valErrs, nil
+}
+
+// fork returns a copy of c validating a range of elements concurrently
+// with c, without splitting the slices it meets.
+func (c *validation) fork() *validation {
+	sub := &validation{
+		opts:   c.opts,
+		cache:  c.cache,
+		root:   c.root,
+		ctx:    c.ctx,
+		only:   c.only,
+		except: c.except,
+		depth:  c.depth,
+		field:  c.field,
+		doc:    c.doc,
+		// Failures found by c count towards the limits of the copy.
+		errCount: c.errCount,
+	}
+	sub.opts.parallelism = 0
+	if c.outcomes != nil {
+		sub.outcomes = make(map[string]Outcome)
+	}
+	if len(c.visiting) > 0 {
+		sub.visiting = make(map[visit]bool, len(c.visiting))
+		for key := range c.visiting {
+			sub.visiting[key] = true
+		}
+	}
+	return sub
+}
@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelism(t *testing.T) {
+	type Record struct {
+		ID    string `validate:"required"`
+		Qty   int    `validate:"min:1;max:10"`
+		Notes []string
+		Kind  string `validate:"warn:in:a,b"`
+	}
+	type Batch struct {
+		Records []Record
+		Fixed   [3]Record
+	}
+	batch := Batch{Records: make([]Record, 1000)}
+	for i := range batch.Records {
+		batch.Records[i] = Record{ID: fmt.Sprint(i), Qty: i%10 + 1, Kind: "a"}
+		if i%7 == 0 {
+			batch.Records[i].Qty = 20
+		}
+		if i%11 == 0 {
+			batch.Records[i].ID = ""
+			batch.Records[i].Kind = "c"
+		}
+	}
+	batch.Fixed[1].Qty = 1
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"all", nil},
+		{"max errors", []Option{WithMaxErrors(150)}},
+		{"fail fast", []Option{WithFailFast()}},
+		{"max depth", []Option{WithMaxDepth(2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := Validate(batch, tt.opts...)
+			for _, n := range []int{2, 3, 8, 5000} {
+				got := Validate(batch, append(tt.opts, WithParallelism(n))...)
+				assert.Equal(t, want, got, "parallelism %d", n)
+			}
+		})
+	}
+
+	want := Check(batch)
+	got := Check(batch, WithParallelism(4))
+	assert.Equal(t, want.Err(), got.Err())
+	assert.Equal(t, want.Warnings(), got.Warnings())
+	for _, path := range []string{".Records[0].ID", ".Records[1].Qty", ".Records[999].Qty", ".Fixed[0].ID"} {
+		assert.Equal(t, want.Outcome(path), got.Outcome(path), path)
+	}
+
+	valid := Record{ID: "a", Qty: 1}
+	assert.NoError(t, Validate(Batch{Records: []Record{valid}, Fixed: [3]Record{valid, valid, valid}}, WithParallelism(4)))
+	assert.Equal(t, Validate(batch), Validate(batch, WithParallelism(1)))
+}
+
+func TestParallelismNestedTags(t *testing.T) {
+	// The tags of the fields holding Items leave room in the tags passed
+	// to its elements, which must not be shared by the goroutines.
+	type Item struct {
+		V int `validate:"min:1"`
+		W int `validate:"max:5"`
+	}
+	type Inner struct {
+		Items []Item `validate:"required"`
+	}
+	type Middle struct {
+		Inner Inner `validate:"required"`
+	}
+	type Outer struct {
+		Middle Middle `validate:"required"`
+	}
+	var outer Outer
+	items := make([]Item, 200)
+	for i := range items {
+		items[i] = Item{V: i % 3, W: i % 8}
+	}
+	outer.Middle.Inner.Items = items
+	want := Validate(outer)
+	assert.Error(t, want)
+	assert.Equal(t, want, Validate(outer, WithParallelism(8)))
+}
@@ -0,0 +1,132 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// tagRule is a single rule of a tag along with its byte offset in the tag.
+type tagRule struct {
+	key   string
+	param param
+	pos   int
+	// warn is set for rules written with the warn modifier.
+	warn bool
+	// role is the role the rule was written for, see WithRole.
+	role string
+}
+
+// pipeSep chains rules in pipeline style, e.g. "trim|lower|min:3|alpha".
+const pipeSep = '|'
+
+// parseTag splits a tag like "min:1;max:10" into its rules. A rule is a name
+// made of lowercase letters, digits and underscores, optionally followed by
+// the key/value separator and a parameter running up to the next rule
+// separator. Rules may also be separated by a single "|" followed by a rule
+// name, so that parameters like "A || B" keep their pipes. The parameter of
+// the msg pseudo-rule runs to the end of the tag, separators included.
+func parseTag(tag string, o *options) ([]tagRule, error) {
+	if o.playground {
+		return parsePlaygroundTag(tag)
+	}
+	var res []tagRule
+	for pos := 0; ; {
+		end, sepLen := nextRuleSep(tag, pos, o)
+		if strings.HasPrefix(tag[pos:], msgTag+string(o.kvSep)) {
+			end = len(tag)
+		}
+		tr, err := lexRule(tag, pos, end, o)
+		if err != nil {
+			return nil, err
+		}
+		if tr.key == msgTag && tr.param.val == "" {
+			return nil, tagSyntaxError(tag, pos, "empty message")
+		}
+		res = append(res, tr)
+		if end == len(tag) {
+			return res, nil
+		}
+		pos = end + sepLen
+	}
+}
+
+// nextRuleSep returns the offset and length of the first rule separator
+// at or after pos, or len(tag) if there is none.
+func nextRuleSep(tag string, pos int, o *options) (int, int) {
+	end := strings.IndexRune(tag[pos:], o.ruleSep)
+	if end < 0 {
+		end = len(tag)
+	} else {
+		end += pos
+	}
+	if o.ruleSep == pipeSep {
+		return end, utf8.RuneLen(o.ruleSep)
+	}
+	for i := pos; i < end; i++ {
+		if tag[i] == pipeSep && (i == 0 || tag[i-1] != pipeSep) && startsRule(tag[i+1:], o) {
+			return i, 1
+		}
+	}
+	return end, utf8.RuneLen(o.ruleSep)
+}
+
+// startsRule reports whether s starts with a rule name ending the rule.
+func startsRule(s string, o *options) bool {
+	n := 0
+	for n < len(s) && isRuleNameByte(s[n], n) {
+		n++
+	}
+	if n == 0 {
+		return false
+	}
+	s = s[n:]
+	return s == "" || s[0] == pipeSep || strings.HasPrefix(s, string(o.kvSep)) || strings.HasPrefix(s, string(o.ruleSep))
+}
+
+// lexRule reads the rule spanning tag[start:end].
+func lexRule(tag string, start, end int, o *options) (tagRule, error) {
+	src := tag[start:end]
+	if len(src) == 0 {
+		return tagRule{}, tagSyntaxError(tag, start, "empty rule")
+	}
+	i := 0
+	for i < len(src) && isRuleNameByte(src[i], i) {
+		i++
+	}
+	if i == 0 {
+		return tagRule{}, tagSyntaxError(tag, start, "rule name expected")
+	}
+	tr := tagRule{key: src[:i], param: param{listSep: string(o.listSep)}, pos: start}
+	if i == len(src) {
+		return tr, nil
+	}
+	kv := string(o.kvSep)
+	if !strings.HasPrefix(src[i:], kv) {
+		return tagRule{}, tagSyntaxError(tag, start+i, fmt.Sprintf("%q expected after rule name %q", kv, tr.key))
+	}
+	tr.param.val = src[i+len(kv):]
+	return tr, nil
+}
+
+// lexRoleRule reads the rule held by role pseudo-rule tr of tag, written
+// role=rule.
+func lexRoleRule(tag string, tr tagRule, o *options) (tagRule, error) {
+	start := tr.pos + len(tr.key) + utf8.RuneLen(o.kvSep)
+	role, _, ok := strings.Cut(tr.param.val, "=")
+	if !ok || role == "" {
+		return tagRule{}, tagSyntaxError(tag, start, `"role=rule" expected`)
+	}
+	start += len(role) + 1
+	res, err := lexRule(tag, start, start+len(tr.param.val)-len(role)-1, o)
+	res.role = role
+	return res, err
+}
+
+func isRuleNameByte(b byte, i int) bool {
+	return 'a' <= b && b <= 'z' || b == '_' || i > 0 && '0' <= b && b <= '9'
+}
+
+func tagSyntaxError(tag string, pos int, msg string) error {
+	return fmt.Errorf("%w: %s at offset %d in %q", ErrInvalidValidatorSyntax, msg, pos, tag)
+}
@@ -0,0 +1,119 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTag(t *testing.T) {
+	o := defaultOptions()
+	tests := []struct {
+		name    string
+		tag     string
+		want    []tagRule
+		wantErr string
+	}{
+		{
+			name: "single rule",
+			tag:  "min:10",
+			want: []tagRule{{key: "min", param: param{val: "10", listSep: ","}, pos: 0}},
+		},
+		{
+			name: "all rules are kept",
+			tag:  "a:1;b:2;c:3",
+			want: []tagRule{
+				{key: "a", param: param{val: "1", listSep: ","}, pos: 0},
+				{key: "b", param: param{val: "2", listSep: ","}, pos: 4},
+				{key: "c", param: param{val: "3", listSep: ","}, pos: 8},
+			},
+		},
+		{
+			name: "rule without parameter",
+			tag:  "required;in:a,b",
+			want: []tagRule{
+				{key: "required", param: param{val: "", listSep: ","}, pos: 0},
+				{key: "in", param: param{val: "a,b", listSep: ","}, pos: 9},
+			},
+		},
+		{
+			name: "pipeline",
+			tag:  "trim|lower|min:3|alpha",
+			want: []tagRule{
+				{key: "trim", param: param{val: "", listSep: ","}, pos: 0},
+				{key: "lower", param: param{val: "", listSep: ","}, pos: 5},
+				{key: "min", param: param{val: "3", listSep: ","}, pos: 11},
+				{key: "alpha", param: param{val: "", listSep: ","}, pos: 17},
+			},
+		},
+		{
+			name: "pipes inside parameter",
+			tag:  "expr:A > 0 || B|trim",
+			want: []tagRule{
+				{key: "expr", param: param{val: "A > 0 || B", listSep: ","}, pos: 0},
+				{key: "trim", param: param{val: "", listSep: ","}, pos: 16},
+			},
+		},
+		{
+			name: "pipe not followed by a rule",
+			tag:  "in:a|b c,d",
+			want: []tagRule{{key: "in", param: param{val: "a|b c,d", listSep: ","}, pos: 0}},
+		},
+		{
+			name: "message runs to the end of the tag",
+			tag:  "min:8;msg:too short; try again|x",
+			want: []tagRule{
+				{key: "min", param: param{val: "8", listSep: ","}, pos: 0},
+				{key: "msg", param: param{val: "too short; try again|x", listSep: ","}, pos: 6},
+			},
+		},
+		{
+			name:    "empty message",
+			tag:     "min:8;msg:",
+			wantErr: `invalid validator syntax: empty message at offset 6 in "min:8;msg:"`,
+		},
+		{
+			name:    "empty rule",
+			tag:     "min:1;;max:2",
+			wantErr: `invalid validator syntax: empty rule at offset 6 in "min:1;;max:2"`,
+		},
+		{
+			name:    "trailing separator",
+			tag:     "min:1;",
+			wantErr: `invalid validator syntax: empty rule at offset 6 in "min:1;"`,
+		},
+		{
+			name:    "missing rule name",
+			tag:     "min:1;:2",
+			wantErr: `invalid validator syntax: rule name expected at offset 6 in "min:1;:2"`,
+		},
+		{
+			name:    "bad character in rule name",
+			tag:     "min:1;ma-x:2",
+			wantErr: `invalid validator syntax: ":" expected after rule name "ma" at offset 8 in "min:1;ma-x:2"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTag(tt.tag, &o)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateEvaluatesEveryRule(t *testing.T) {
+	err := Validate(struct {
+		Num int `validate:"min:1;in:5,7;max:10"`
+	}{Num: 3})
+	assert.EqualError(t, err, `.Num: validation failed for "in" tag`)
+
+	err = Validate(struct {
+		Num int `validate:"min:1;inn:5"`
+	}{Num: 3})
+	assert.EqualError(t, err, `invalid validator syntax: unsupported tag "inn" at offset 6`)
+}
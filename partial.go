@@ -0,0 +1,118 @@
+package validate
+
+import "strings"
+
+// WithPartial restricts validation to the fields matched by selectors.
+// A selector is a path like "Items[*].Name": it matches the fields at that
+// path and everything nested in them. [*] matches any slice index or map
+// value and [key=*] any map key.
+func WithPartial(selectors ...string) Option {
+	return func(o *options) {
+		o.only = append(o.only, selectors...)
+	}
+}
+
+// WithExcept skips the fields matched by selectors, see WithPartial.
+func WithExcept(selectors ...string) Option {
+	return func(o *options) {
+		o.except = append(o.except, selectors...)
+	}
+}
+
+// ValidatePartial validates only the fields of s matched by selectors.
+func (v *Validator) ValidatePartial(s any, selectors ...string) error {
+	return v.Validate(s, WithPartial(selectors...))
+}
+
+// ValidateExcept validates all fields of s but the ones matched by selectors.
+func (v *Validator) ValidateExcept(s any, selectors ...string) error {
+	return v.Validate(s, WithExcept(selectors...))
+}
+
+// ValidatePartial validates only the fields of v matched by selectors with
+// the package default Validator.
+func ValidatePartial(v any, selectors ...string) error {
+	return defaultValidator.ValidatePartial(v, selectors...)
+}
+
+// ValidateExcept validates all fields of v but the ones matched by
+// selectors with the package default Validator.
+func ValidateExcept(v any, selectors ...string) error {
+	return defaultValidator.ValidateExcept(v, selectors...)
+}
+
+type pathSelector []PathSegment
+
+func parseSelectors(selectors []string) ([]pathSelector, error) {
+	if len(selectors) == 0 {
+		return nil, nil
+	}
+	res := make([]pathSelector, 0, len(selectors))
+	for _, s := range selectors {
+		if !strings.HasPrefix(s, ".") && !strings.HasPrefix(s, "[") {
+			s = "." + s
+		}
+		segs, err := ParseFieldPath(s)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, segs)
+	}
+	return res, nil
+}
+
+// covers reports whether sel matches path or one of its ancestors.
+func (sel pathSelector) covers(path []PathSegment) bool {
+	return len(sel) <= len(path) && sel.matchPrefix(path)
+}
+
+// leadsTo reports whether sel matches a descendant of path.
+func (sel pathSelector) leadsTo(path []PathSegment) bool {
+	return len(sel) > len(path) && pathSelector(sel[:len(path)]).matchPrefix(path)
+}
+
+func (sel pathSelector) matchPrefix(path []PathSegment) bool {
+	for i, seg := range sel {
+		p := path[i]
+		if seg.Field != p.Field || seg.MapKey != p.MapKey || seg.Key != p.Key && seg.Key != "*" {
+			return false
+		}
+	}
+	return true
+}
+
+type selection int
+
+const (
+	// selectAll validates a path and everything nested in it.
+	selectAll selection = iota
+	// selectNested only validates some paths nested in a path.
+	selectNested
+	selectNone
+)
+
+func (c *validation) selection(path string) selection {
+	if c.only == nil && c.except == nil {
+		return selectAll
+	}
+	// Paths built during traversal always parse.
+	segs, _ := ParseFieldPath(path)
+	for _, sel := range c.except {
+		if sel.covers(segs) {
+			return selectNone
+		}
+	}
+	if c.only == nil {
+		return selectAll
+	}
+	res := selectNone
+	for _, sel := range c.only {
+		if sel.covers(segs) {
+			return selectAll
+		}
+		if sel.leadsTo(segs) {
+			res = selectNested
+		}
+	}
+	return res
+}
@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePartial(t *testing.T) {
+	type item struct {
+		Name string `validate:"min:3"`
+		Qty  int    `validate:"min:1"`
+	}
+	type order struct {
+		ID    int `validate:"min:1"`
+		Items []item
+		Zips  map[string]string `validate:"len:5"`
+	}
+	o := order{
+		Items: []item{{"ab", 0}, {"abc", 0}},
+		Zips:  map[string]string{"home": "123", "work": "12345"},
+	}
+	tests := []struct {
+		name      string
+		err       error
+		wantPaths []string
+	}{
+		{
+			name:      "wildcard index",
+			err:       ValidatePartial(o, "Items[*].Name"),
+			wantPaths: []string{".Items[0].Name"},
+		},
+		{
+			name:      "whole subtree",
+			err:       ValidatePartial(o, ".Items[1]", "ID"),
+			wantPaths: []string{".ID", ".Items[1].Qty"},
+		},
+		{
+			name:      "wildcard map value",
+			err:       ValidatePartial(o, `Zips[*]`),
+			wantPaths: []string{`.Zips["home"]`},
+		},
+		{
+			name:      "except wildcard",
+			err:       ValidateExcept(o, "Items[*].Qty", `Zips["home"]`),
+			wantPaths: []string{".ID", ".Items[0].Name"},
+		},
+		{
+			name:      "partial and except",
+			err:       Validate(o, WithPartial("Items"), WithExcept("Items[0]")),
+			wantPaths: []string{".Items[1].Qty"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			for _, err := range tt.err.(ValidationErrors) {
+				paths = append(paths, err.Path)
+			}
+			assert.Equal(t, tt.wantPaths, paths)
+		})
+	}
+
+	assert.NoError(t, ValidatePartial(o, "Items[*].Missing"))
+	assert.ErrorContains(t, ValidatePartial(o, "Items[*"), ErrInvalidFieldPath.Error())
+}
@@ -0,0 +1,240 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var ErrInvalidFieldPath = errors.New("invalid field path")
+
+// PathSegment is a single step of an error path such as .Limits["cpu"].Max.
+// Exactly one of Field and Key is set.
+type PathSegment struct {
+	// Field is the name of a struct field.
+	Field string
+	// Key is a slice or array index, or the canonical form of a map key
+	// as returned by FormatMapKey.
+	Key string
+	// MapKey is set when the segment refers to the map key itself
+	// rather than to the value stored under it.
+	MapKey bool
+}
+
+func (s PathSegment) String() string {
+	switch {
+	case s.MapKey:
+		return "[key=" + s.Key + "]"
+	case s.Key != "":
+		return "[" + s.Key + "]"
+	case isPlainFieldName(s.Field):
+		return "." + s.Field
+	}
+	return "." + strconv.Quote(s.Field)
+}
+
+// FormatFieldPath joins segments into a path, the inverse of ParseFieldPath.
+func FormatFieldPath(segs []PathSegment) string {
+	var sb strings.Builder
+	for _, seg := range segs {
+		sb.WriteString(seg.String())
+	}
+	return sb.String()
+}
+
+// FormatJSONPointer writes segments as a JSON Pointer (RFC 6901), e.g.
+// /items/2/name for .items[2].name. Map keys are unquoted, and keys
+// themselves are located like the values stored under them.
+func FormatJSONPointer(segs []PathSegment) string {
+	var sb strings.Builder
+	for _, seg := range segs {
+		token := seg.Field
+		if seg.Field == "" {
+			token = seg.Key
+			if unquoted, err := strconv.Unquote(seg.Key); err == nil {
+				token = unquoted
+			}
+		}
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(token))
+	}
+	return sb.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// ParseFieldPath splits an error path into its segments. Field names which
+// are not identifiers are quoted in paths, e.g. ."first.name", and map keys
+// use the quoting of FormatMapKey, so any path built by this package parses
+// back to the segments it was built from.
+func ParseFieldPath(path string) ([]PathSegment, error) {
+	var segs []PathSegment
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			i++
+			if i < len(path) && path[i] == '"' {
+				quoted, err := strconv.QuotedPrefix(path[i:])
+				if err != nil {
+					return nil, pathError(path, i, "unterminated field name")
+				}
+				name, _ := strconv.Unquote(quoted)
+				segs = append(segs, PathSegment{Field: name})
+				i += len(quoted)
+				continue
+			}
+			j := i + plainFieldNameLen(path[i:])
+			if j == i {
+				return nil, pathError(path, i, "field name expected")
+			}
+			segs = append(segs, PathSegment{Field: path[i:j]})
+			i = j
+		case '[':
+			end, err := closingBracket(path, i)
+			if err != nil {
+				return nil, err
+			}
+			seg := PathSegment{Key: path[i+1 : end]}
+			if key, ok := strings.CutPrefix(seg.Key, "key="); ok {
+				seg.Key, seg.MapKey = key, true
+			}
+			if seg.Key == "" {
+				return nil, pathError(path, i+1, "key expected")
+			}
+			segs = append(segs, seg)
+			i = end + 1
+		default:
+			return nil, pathError(path, i, fmt.Sprintf("unexpected %q", path[i]))
+		}
+	}
+	return segs, nil
+}
+
+// closingBracket returns the offset of the "]" closing the "[" at path[start],
+// skipping nested brackets, braces and quoted strings.
+func closingBracket(path string, start int) (int, error) {
+	depth := 0
+	for i := start; i < len(path); i++ {
+		switch path[i] {
+		case '"':
+			quoted, err := strconv.QuotedPrefix(path[i:])
+			if err != nil {
+				return 0, pathError(path, i, "unterminated string")
+			}
+			i += len(quoted) - 1
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+			if depth == 0 {
+				if path[i] != ']' {
+					return 0, pathError(path, i, "unbalanced brackets")
+				}
+				return i, nil
+			}
+		}
+	}
+	return 0, pathError(path, start, "unclosed bracket")
+}
+
+func pathError(path string, pos int, msg string) error {
+	return fmt.Errorf("%w: %s at offset %d in %q", ErrInvalidFieldPath, msg, pos, path)
+}
+
+// plainFieldNameLen returns the length of the identifier prefixing s.
+func plainFieldNameLen(s string) int {
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !unicode.IsLetter(r) && r != '_' && (n == 0 || !unicode.IsDigit(r)) {
+			break
+		}
+		n += size
+	}
+	return n
+}
+
+func isPlainFieldName(name string) bool {
+	return name != "" && plainFieldNameLen(name) == len(name)
+}
+
+// fieldPath extends callstack with a struct field.
+func fieldPath(callstack, name string) string {
+	return callstack + PathSegment{Field: name}.String()
+}
+
+// FormatMapKey returns the canonical form of a map key as used in error
+// paths: strings are quoted, numbers and booleans are written as Go
+// literals, and structs and arrays list their elements, e.g.
+// {Region:"eu",Zone:2} or [1,2]. Keys of other kinds are quoted.
+func FormatMapKey(key any) string {
+	return formatMapKey(reflect.ValueOf(key))
+}
+
+func formatMapKey(k reflect.Value) string {
+	switch k.Kind() {
+	case reflect.Invalid:
+		return "nil"
+	case reflect.String:
+		return strconv.Quote(k.String())
+	case reflect.Bool:
+		return strconv.FormatBool(k.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(k.Float(), 'g', -1, 64)
+	case reflect.Pointer, reflect.Interface:
+		if k.IsNil() {
+			return "nil"
+		}
+		return formatMapKey(k.Elem())
+	case reflect.Struct:
+		fields := make([]string, k.NumField())
+		for i := range fields {
+			fields[i] = k.Type().Field(i).Name + ":" + formatMapKey(k.Field(i))
+		}
+		return "{" + strings.Join(fields, ",") + "}"
+	case reflect.Array:
+		elems := make([]string, k.Len())
+		for i := range elems {
+			elems[i] = formatMapKey(k.Index(i))
+		}
+		return "[" + strings.Join(elems, ",") + "]"
+	}
+	return strconv.Quote(fmt.Sprintf("%v", k))
+}
+
+type mapKey struct {
+	val       reflect.Value
+	formatted string
+}
+
+// sortedMapKeys returns the keys of map m ordered by their canonical form,
+// so that errors are reported in a stable order.
+func sortedMapKeys(m reflect.Value) []mapKey {
+	keys := make([]mapKey, 0, m.Len())
+	for _, key := range m.MapKeys() {
+		keys = append(keys, mapKey{key, formatMapKey(key)})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].formatted < keys[j].formatted
+	})
+	return keys
+}
+
+// mapKeyPath and mapValuePath extend callstack with a map element:
+// values are written as ["cpu"], keys themselves as [key="cpu"].
+func mapKeyPath(callstack, key string) string {
+	return callstack + PathSegment{Key: key, MapKey: true}.String()
+}
+
+func mapValuePath(callstack, key string) string {
+	return callstack + PathSegment{Key: key}.String()
+}
@@ -0,0 +1,135 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatMapKey(t *testing.T) {
+	type region struct {
+		Name string
+		Zone int
+	}
+	zone := 3
+	tests := []struct {
+		key  any
+		want string
+	}{
+		{key: "cpu", want: `"cpu"`},
+		{key: `a"b`, want: `"a\"b"`},
+		{key: -12, want: "-12"},
+		{key: uint8(7), want: "7"},
+		{key: 1.5, want: "1.5"},
+		{key: true, want: "true"},
+		{key: &zone, want: "3"},
+		{key: nil, want: "nil"},
+		{key: region{"eu", 2}, want: `{Name:"eu",Zone:2}`},
+		{key: [2]int{1, 2}, want: "[1,2]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatMapKey(tt.key))
+		})
+	}
+}
+
+func TestValidateMapPaths(t *testing.T) {
+	type limit struct {
+		Max int `validate:"min:1"`
+	}
+	type region struct {
+		Name string `validate:"len:2"`
+	}
+	err := Validate(struct {
+		Limits  map[string]limit
+		Regions map[region]int `validate:"max:10"`
+	}{
+		Limits:  map[string]limit{"mem": {0}, "cpu": {0}, "io": {1}},
+		Regions: map[region]int{{"eu"}: 20, {"usa"}: 1},
+	})
+	assert.EqualError(t, err, `.Limits["cpu"].Max: validation failed for "min" tag`+
+		`; .Limits["mem"].Max: validation failed for "min" tag`+
+		`; .Regions[{Name:"eu"}]: validation failed for "max" tag`+
+		`; .Regions[key={Name:"usa"}].Name: validation failed for "len" tag`)
+}
+
+func TestParseFieldPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    []PathSegment
+		wantErr string
+	}{
+		{
+			path: `.Limits["cpu"].Max`,
+			want: []PathSegment{{Field: "Limits"}, {Key: `"cpu"`}, {Field: "Max"}},
+		},
+		{
+			path: `.Items[2].Tags[key="a]b"]`,
+			want: []PathSegment{{Field: "Items"}, {Key: "2"}, {Field: "Tags"}, {Key: `"a]b"`, MapKey: true}},
+		},
+		{
+			path: `.Regions[{Name:"e}u",Codes:[1,2]}]."first.name"`,
+			want: []PathSegment{{Field: "Regions"}, {Key: `{Name:"e}u",Codes:[1,2]}`}, {Field: "first.name"}},
+		},
+		{
+			path: `.Имя[0]`,
+			want: []PathSegment{{Field: "Имя"}, {Key: "0"}},
+		},
+		{path: `.Items[2`, wantErr: `invalid field path: unclosed bracket at offset 6 in ".Items[2"`},
+		{path: `.Items[]`, wantErr: `invalid field path: key expected at offset 7 in ".Items[]"`},
+		{path: `.Items[{]`, wantErr: `invalid field path: unclosed bracket at offset 6 in ".Items[{]"`},
+		{path: `.1st`, wantErr: `invalid field path: field name expected at offset 1 in ".1st"`},
+		{path: `Items`, wantErr: `invalid field path: unexpected 'I' at offset 0 in "Items"`},
+		{path: `."a`, wantErr: `invalid field path: unterminated field name at offset 1 in ".\"a"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := ParseFieldPath(tt.path)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.path, FormatFieldPath(got))
+		})
+	}
+}
+
+func TestFormatJSONPointer(t *testing.T) {
+	for _, tt := range []struct {
+		path, want string
+	}{
+		{"", ""},
+		{".items[2].name", "/items/2/name"},
+		{`.Limits["cpu"].Max`, "/Limits/cpu/Max"},
+		{`.Labels[key="a/b~c"]`, "/Labels/a~1b~0c"},
+		{`."first.name"`, "/first.name"},
+		{".Ports[8080]", "/Ports/8080"},
+	} {
+		segs, err := ParseFieldPath(tt.path)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, FormatJSONPointer(segs), tt.path)
+	}
+}
+
+func TestJSONPointerPaths(t *testing.T) {
+	type Item struct {
+		Name string `json:"name" validate:"min:2"`
+	}
+	type Order struct {
+		Items []Item            `json:"items"`
+		Notes map[string]string `json:"notes" validate:"values:max:3"`
+	}
+	order := Order{Items: []Item{{"ok"}, {"ok"}, {"x"}}, Notes: map[string]string{"a/b": "long"}}
+	var valErrs ValidationErrors
+	assert.ErrorAs(t, Validate(order, WithJSONFieldNames(), WithJSONPointerPaths()), &valErrs)
+	assert.Len(t, valErrs, 2)
+	assert.Equal(t, "/items/2/name", valErrs[0].Path)
+	assert.EqualError(t, valErrs[0], `/items/2/name: validation failed for "min" tag`)
+	assert.Equal(t, "/notes/a~1b", valErrs[1].Path)
+
+	assert.ErrorAs(t, Validate(order, WithJSONPointerPaths(), WithPartial("Items[*].Name")), &valErrs)
+	assert.Equal(t, "/Items/2/Name", valErrs[0].Path)
+}
@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"strconv"
+	"strings"
+)
+
+// maxDecimals reports whether f has at most the number of decimals given
+// by the parameter p, if any.
+func maxDecimals(f float64, p param) (bool, error) {
+	if p.val == "" {
+		return true, nil
+	}
+	n, err := p.int()
+	if err != nil || n < 0 {
+		return false, ErrInvalidValidatorSyntax
+	}
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	_, frac, _ := strings.Cut(s, ".")
+	return int64(len(frac)) <= n, nil
+}
+
+// percentRule requires a percentage from 0 to 100. Its optional parameter
+// bounds the number of decimals of floats, e.g. percent:2 allows 12.34.
+var percentRule = rule{
+	assertInt: func(val int64, p param) (bool, error) {
+		return 0 <= val && val <= 100, nil
+	},
+	assertUint: func(val uint64, p param) (bool, error) {
+		return val <= 100, nil
+	},
+	assertFloat: func(val float64, p param) (bool, error) {
+		if !(0 <= val && val <= 100) {
+			return false, nil
+		}
+		return maxDecimals(val, p)
+	},
+	noParam: true,
+}
+
+// bpsRule requires an integer amount of basis points, from 0 to 10000.
+var bpsRule = rule{
+	assertInt: func(val int64, p param) (bool, error) {
+		return 0 <= val && val <= 10000, nil
+	},
+	assertUint: func(val uint64, p param) (bool, error) {
+		return val <= 10000, nil
+	},
+	noParam: true,
+}
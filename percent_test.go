@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentAndBps(t *testing.T) {
+	tests := []struct {
+		tag   string
+		val   any
+		valid bool
+	}{
+		{"percent", 0, true},
+		{"percent", 100, true},
+		{"percent", -1, false},
+		{"percent", uint8(101), false},
+		{"percent", 12.3456, true},
+		{"percent", 100.01, false},
+		{"percent", math.NaN(), false},
+		{"percent:2", 12.34, true},
+		{"percent:2", 12.345, false},
+		{"percent:0", 12.0, true},
+		{"percent:0", 12.5, false},
+		{"bps", 0, true},
+		{"bps", 10000, true},
+		{"bps", 10001, false},
+		{"bps", -5, false},
+		{"bps", uint(250), true},
+	}
+	for _, tt := range tests {
+		err := Var(tt.val, tt.tag)
+		if tt.valid {
+			assert.NoError(t, err, "%s %v", tt.tag, tt.val)
+		} else {
+			assert.Error(t, err, "%s %v", tt.tag, tt.val)
+		}
+	}
+	assert.EqualError(t, Var(2.5, "bps"), "unsupported type float64")
+	assert.Equal(t, ValidationErrors{{Err: ErrInvalidValidatorSyntax}}, Var(2.5, "percent:x"))
+}
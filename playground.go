@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// playgroundRules maps go-playground/validator rule names to the rules
+// of this package implementing them.
+var playgroundRules = map[string]string{
+	"required":  "required",
+	"omitempty": "omitempty",
+	"len":       "len",
+	"min":       "min",
+	"max":       "max",
+	"gte":       "min",
+	"lte":       "max",
+	"gt":        "gt",
+	"lt":        "lt",
+	"oneof":     "in",
+	"email":     "email",
+	"url":       "url",
+	"eqfield":   "eqfield",
+	"nefield":   "nefield",
+	"gtfield":   "gtfield",
+	"ltfield":   "ltfield",
+}
+
+// parsePlaygroundTag parses a tag written in the go-playground/validator
+// dialect: comma separated rules with "=" before the parameter and space
+// separated oneof sets.
+func parsePlaygroundTag(tag string) ([]tagRule, error) {
+	var res []tagRule
+	pos := 0
+	for _, part := range strings.Split(tag, ",") {
+		name, val, _ := strings.Cut(part, "=")
+		key, ok := playgroundRules[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported tag %q at offset %d", ErrInvalidValidatorSyntax, name, pos)
+		}
+		res = append(res, tagRule{key: key, param: param{val: val, listSep: " "}, pos: pos})
+		pos += len(part) + 1
+	}
+	return res, nil
+}
@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaygroundSyntax(t *testing.T) {
+	type user struct {
+		Name  string `validate:"required,min=3,max=20"`
+		Role  string `validate:"oneof=admin user"`
+		Age   int    `validate:"gte=18,lte=130"`
+		Phone string `validate:"len=10"`
+		Email string `validate:"required,email"`
+		Site  string `validate:"omitempty,url"`
+	}
+	v := New(WithPlaygroundSyntax())
+	tests := []struct {
+		name    string
+		v       any
+		wantLen int
+	}{
+		{
+			name: "valid",
+			v:    user{Name: "alice", Role: "user", Age: 30, Phone: "0123456789", Email: "alice@example.com"},
+		},
+		{
+			name:    "invalid",
+			v:       user{Name: "", Role: "guest", Age: 12, Phone: "123", Email: "alice", Site: "not a url"},
+			wantLen: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.v)
+			if tt.wantLen == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.Len(t, err.(ValidationErrors), tt.wantLen)
+			}
+		})
+	}
+
+	t.Run("strict bounds and runes", func(t *testing.T) {
+		type item struct {
+			Name  string `validate:"min=2,max=4"`
+			Code  string `validate:"len=3"`
+			Price int    `validate:"gt=0,lt=10"`
+		}
+		assert.NoError(t, v.Validate(item{Name: "héé", Code: "äöü", Price: 9}))
+		err := v.Validate(item{Name: "ü", Code: "ab", Price: 10})
+		assert.Len(t, err.(ValidationErrors), 3)
+		// Native tags count bytes.
+		assert.Error(t, Validate(struct {
+			Code string `validate:"len:3"`
+		}{"äöü"}))
+	})
+
+	t.Run("unsupported rule", func(t *testing.T) {
+		err := v.Validate(struct {
+			Code string `validate:"required,alphanum"`
+		}{})
+		assert.ErrorContains(t, err, `unsupported tag "alphanum"`)
+	})
+}
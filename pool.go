@@ -0,0 +1,110 @@
+package validate
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// Pool recycles values of struct type T, e.g. request structs, through a
+// decode-validate-handle loop, so that allocation-sensitive servers do not
+// allocate a value per request. Values are reset before being reused:
+// slices keep their backing arrays and maps their storage. A Pool is safe
+// for concurrent use.
+type Pool[T any] struct {
+	v     ValidatorIface
+	opts  []Option
+	pool  sync.Pool
+	reset func(reflect.Value)
+}
+
+// NewPool returns a Pool validating values with v and opts.
+func NewPool[T any](v ValidatorIface, opts ...Option) *Pool[T] {
+	return &Pool[T]{
+		v:     v,
+		opts:  opts,
+		pool:  sync.Pool{New: func() any { return new(T) }},
+		reset: resetterOf(reflect.TypeOf((*T)(nil)).Elem()),
+	}
+}
+
+// Get returns a zero value from the pool, to be given back with Put.
+func (p *Pool[T]) Get() *T {
+	return p.pool.Get().(*T)
+}
+
+// Put resets t and gives it back to the pool. t must not be used after.
+func (p *Pool[T]) Put(t *T) {
+	p.reset(reflect.ValueOf(t).Elem())
+	p.pool.Put(t)
+}
+
+// Handle gets a value from the pool, fills it with decode, validates it and
+// passes it to handle, then puts it back. It returns the first error of
+// decode, of validation or of handle. handle must not retain the value.
+func (p *Pool[T]) Handle(decode func(*T) error, handle func(*T) error) error {
+	return p.HandleCtx(context.Background(), decode, handle)
+}
+
+// HandleCtx is Handle validating values with ctx, see ValidateCtx.
+func (p *Pool[T]) HandleCtx(ctx context.Context, decode func(*T) error, handle func(*T) error) error {
+	t := p.Get()
+	defer p.Put(t)
+	if err := decode(t); err != nil {
+		return err
+	}
+	if err := p.v.ValidateCtx(ctx, t, p.opts...); err != nil {
+		return err
+	}
+	return handle(t)
+}
+
+// resetters caches the resetters of types by reflect.Type.
+var resetters sync.Map
+
+// resetterOf returns the function setting values of type t to zero while
+// keeping the storage of their slices and maps for reuse. Structs with
+// unexported fields are reset as a whole, their storage being lost.
+func resetterOf(t reflect.Type) func(reflect.Value) {
+	if r, ok := resetters.Load(t); ok {
+		return r.(func(reflect.Value))
+	}
+	var r func(reflect.Value)
+	switch t.Kind() {
+	case reflect.Slice:
+		r = func(v reflect.Value) {
+			for i := 0; i < v.Len(); i++ {
+				v.Index(i).SetZero()
+			}
+			v.SetLen(0)
+		}
+	case reflect.Map:
+		r = func(v reflect.Value) {
+			for _, k := range v.MapKeys() {
+				v.SetMapIndex(k, reflect.Value{})
+			}
+		}
+	case reflect.Struct:
+		fields := make([]func(reflect.Value), t.NumField())
+		for i := range fields {
+			if !t.Field(i).IsExported() {
+				fields = nil
+				break
+			}
+			fields[i] = resetterOf(t.Field(i).Type)
+		}
+		if fields == nil {
+			r = reflect.Value.SetZero
+			break
+		}
+		r = func(v reflect.Value) {
+			for i, reset := range fields {
+				reset(v.Field(i))
+			}
+		}
+	default:
+		r = reflect.Value.SetZero
+	}
+	resetters.Store(t, r)
+	return r
+}
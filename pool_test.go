@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	type Line struct {
+		SKU string `json:"sku" validate:"min:3"`
+	}
+	type Order struct {
+		ID     string            `json:"id" validate:"required"`
+		Lines  []Line            `json:"lines"`
+		Labels map[string]string `json:"labels"`
+		Meta   struct{ Tag string }
+	}
+	pool := NewPool[Order](New())
+
+	var handled Order
+	err := pool.Handle(func(o *Order) error {
+		return json.Unmarshal([]byte(`{"id":"o1","lines":[{"sku":"abc"},{"sku":"def"}],"labels":{"a":"b"}}`), o)
+	}, func(o *Order) error {
+		handled = *o
+		handled.Lines = append([]Line(nil), o.Lines...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "o1", handled.ID)
+	assert.Len(t, handled.Lines, 2)
+
+	err = pool.Handle(func(o *Order) error {
+		return json.Unmarshal([]byte(`{"id":"o2","lines":[{"sku":"x"}]}`), o)
+	}, func(o *Order) error {
+		t.Fatal("invalid order handled")
+		return nil
+	})
+	assert.ErrorIs(t, err, RuleFailed("min"))
+
+	decodeErr := errors.New("bad json")
+	assert.Equal(t, decodeErr, pool.Handle(func(*Order) error { return decodeErr }, nil))
+
+	o := &Order{ID: "x", Lines: make([]Line, 3, 8), Labels: map[string]string{"k": "v"}}
+	o.Lines[0].SKU = "abc"
+	o.Meta.Tag = "t"
+	lines := o.Lines
+	pool.reset(reflect.ValueOf(o).Elem())
+	assert.Equal(t, "", o.ID)
+	assert.Len(t, o.Lines, 0)
+	assert.Equal(t, 8, cap(o.Lines))
+	assert.Equal(t, "", lines[0].SKU)
+	assert.NotNil(t, o.Labels)
+	assert.Len(t, o.Labels, 0)
+	assert.Equal(t, "", o.Meta.Tag)
+}
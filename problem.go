@@ -0,0 +1,96 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ProblemDetails is an RFC 7807 problem document describing validation
+// failures, with the failures listed by the errors extension member. It
+// is an http.Handler writing itself as application/problem+json.
+type ProblemDetails struct {
+	Type     string           `json:"type"`
+	Title    string           `json:"title"`
+	Status   int              `json:"status"`
+	Detail   string           `json:"detail,omitempty"`
+	Instance string           `json:"instance,omitempty"`
+	Errors   ValidationErrors `json:"errors"`
+}
+
+// ToProblemDetails returns the problem document of v for a response with
+// the given HTTP status, usually http.StatusUnprocessableEntity, about
+// instance, e.g. the request path, which may be empty.
+func (v ValidationErrors) ToProblemDetails(status int, instance string) ProblemDetails {
+	detail := "1 validation error"
+	if len(v) != 1 {
+		detail = fmt.Sprintf("%d validation errors", len(v))
+	}
+	return ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Errors:   v,
+	}
+}
+
+// StatusMap maps the rules of validation failures to HTTP status codes,
+// e.g. quota limits to 403 and size limits to 413, instead of a blanket
+// 422:
+//
+//	statuses := StatusMap{Rules: map[string]int{
+//		"quota": http.StatusForbidden,
+//		"max":   http.StatusRequestEntityTooLarge,
+//	}}
+//	statuses.ProblemDetails(valErrs, r.URL.Path).ServeHTTP(w, r)
+type StatusMap struct {
+	// Rules holds the status of the failures of rules by rule name.
+	Rules map[string]int
+	// Default is the status of the failures of the other rules,
+	// http.StatusUnprocessableEntity when zero.
+	Default int
+}
+
+// Status returns the status of a response describing errs: the lowest
+// status of their failures, so that e.g. failures of authorization rules
+// take precedence over those of the payload.
+func (m StatusMap) Status(errs ValidationErrors) int {
+	def := m.Default
+	if def == 0 {
+		def = http.StatusUnprocessableEntity
+	}
+	res := 0
+	for _, fe := range errs {
+		status, ok := m.Rules[fe.Rule]
+		if !ok {
+			status = def
+		}
+		if res == 0 || status < res {
+			res = status
+		}
+	}
+	if res == 0 {
+		return def
+	}
+	return res
+}
+
+// ProblemDetails returns the problem document of errs about instance, with
+// the status given by m, see ToProblemDetails.
+func (m StatusMap) ProblemDetails(errs ValidationErrors, instance string) ProblemDetails {
+	return errs.ToProblemDetails(m.Status(errs), instance)
+}
+
+// ServeHTTP writes p as the response, with p.Status as status code.
+func (p ProblemDetails) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(p.Status)
+	w.Write(body)
+}
@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemDetails(t *testing.T) {
+	type Signup struct {
+		Email string `json:"email" validate:"email"`
+		Age   int    `json:"age" validate:"min:18"`
+	}
+	var valErrs ValidationErrors
+	assert.True(t, errors.As(Validate(Signup{Email: "bob", Age: 7}, WithJSONFieldNames()), &valErrs))
+
+	problem := valErrs.ToProblemDetails(http.StatusUnprocessableEntity, "/signup")
+	assert.Equal(t, "Unprocessable Entity", problem.Title)
+	assert.Equal(t, "2 validation errors", problem.Detail)
+
+	req := httptest.NewRequest(http.MethodPost, "/signup", nil)
+	rec := httptest.NewRecorder()
+	problem.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Unprocessable Entity",
+		"status": 422,
+		"detail": "2 validation errors",
+		"instance": "/signup",
+		"errors": [
+			{"field": "Email", "path": ".email", "rule": "email", "param": "", "code": "VAL_EMAIL",
+				"message": ".email: validation failed for \"email\" tag"},
+			{"field": "Age", "path": ".age", "rule": "min", "param": "18", "code": "VAL_MIN",
+				"message": ".age: validation failed for \"min\" tag"}
+		]
+	}`, rec.Body.String())
+
+	assert.Equal(t, "1 validation error", valErrs[:1].ToProblemDetails(400, "").Detail)
+
+	statuses := StatusMap{Rules: map[string]int{"email": http.StatusForbidden, "min": http.StatusRequestEntityTooLarge}}
+	assert.Equal(t, http.StatusForbidden, statuses.Status(valErrs))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, statuses.ProblemDetails(valErrs[1:], "").Status)
+	assert.Equal(t, "Request Entity Too Large", statuses.ProblemDetails(valErrs[1:], "").Title)
+	assert.Equal(t, http.StatusUnprocessableEntity, StatusMap{}.Status(valErrs))
+	assert.Equal(t, http.StatusBadRequest, StatusMap{Rules: statuses.Rules, Default: http.StatusBadRequest}.Status(ValidationErrors{{Rule: "len"}, {Rule: "min"}}))
+	assert.Equal(t, http.StatusBadRequest, StatusMap{Default: http.StatusBadRequest}.Status(nil))
+}
@@ -0,0 +1,65 @@
+package validate
+
+import (
+	"context"
+	"fmt"
+)
+
+// QuotaProvider resolves the business limits checked by the quota rule,
+// e.g. the maximum number of projects of the plan of the current account.
+type QuotaProvider interface {
+	Quota(ctx context.Context, name string) (int64, error)
+}
+
+// WithQuotaProvider sets the provider of the limits checked by the quota
+// rule: "quota:max_projects" requires an integer to be at most the limit
+// named max_projects. Like min and max, it checks the size of maps with
+// keys: or values: sections. The provider is given the context passed to
+// ValidateCtx.
+func WithQuotaProvider(p QuotaProvider) Option {
+	return func(o *options) {
+		o.quotas = p
+	}
+}
+
+// ValidateCtx validates v with the package default Validator.
+func ValidateCtx(ctx context.Context, v any, opts ...Option) error {
+	return defaultValidator.ValidateCtx(ctx, v, opts...)
+}
+
+// ValidateCtx validates s like Validate, passing ctx to the providers
+// rules depend on, like the QuotaProvider.
+func (v *Validator) ValidateCtx(ctx context.Context, s any, opts ...Option) error {
+	return v.checkCtx(ctx, s, opts, false).Err()
+}
+
+// quota returns the limit named by the parameter of the quota rule.
+func (p param) quota() (int64, error) {
+	if p.opts.quotas == nil {
+		return 0, fmt.Errorf("quota %q: no QuotaProvider, see WithQuotaProvider", p.val)
+	}
+	ctx := p.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	limit, err := p.opts.quotas.Quota(ctx, p.val)
+	if err != nil {
+		return 0, fmt.Errorf("quota %q: %v", p.val, err)
+	}
+	return limit, nil
+}
+
+var quotaRule = rule{
+	assertInt: func(val int64, p param) (bool, error) {
+		limit, err := p.quota()
+		return val <= limit, err
+	},
+	assertUint: func(val uint64, p param) (bool, error) {
+		limit, err := p.quota()
+		return limit >= 0 && val <= uint64(limit), err
+	},
+	assertSize: func(n int64, p param) (bool, error) {
+		limit, err := p.quota()
+		return n <= limit, err
+	},
+}
@@ -0,0 +1,62 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type planKeyCtx struct{}
+
+// planQuotas resolves limits by the plan stored in the context.
+type planQuotas map[string]map[string]int64
+
+func (q planQuotas) Quota(ctx context.Context, name string) (int64, error) {
+	plan, _ := ctx.Value(planKeyCtx{}).(string)
+	limit, ok := q[plan][name]
+	if !ok {
+		return 0, errors.New("unknown limit")
+	}
+	return limit, nil
+}
+
+func TestQuota(t *testing.T) {
+	type workspace struct {
+		Projects int            `validate:"min:0;quota:max_projects"`
+		Seats    uint           `validate:"quota:max_seats"`
+		Labels   map[string]int `validate:"quota:max_labels;values:min:1"`
+	}
+	v := New(WithQuotaProvider(planQuotas{
+		"free": {"max_projects": 3, "max_seats": 1, "max_labels": 1},
+		"pro":  {"max_projects": 100, "max_seats": 50, "max_labels": 10},
+	}))
+	free := context.WithValue(context.Background(), planKeyCtx{}, "free")
+	pro := context.WithValue(context.Background(), planKeyCtx{}, "pro")
+	ws := workspace{Projects: 10, Seats: 5, Labels: map[string]int{"a": 1, "b": 2}}
+
+	assert.NoError(t, v.ValidateCtx(pro, ws))
+	err := v.ValidateCtx(free, ws)
+	assert.EqualError(t, err, `.Projects: validation failed for "quota" tag`+
+		`; .Seats: validation failed for "quota" tag`+
+		`; .Labels: validation failed for "quota" tag`)
+
+	err = v.ValidateCtx(context.Background(), ws)
+	assert.EqualError(t, err, `quota "max_projects": unknown limit`)
+
+	// Validators taken as a ValidatorIface pass the context on too.
+	events := NewEventRegistry(v)
+	assert.NoError(t, events.Register("workspace.updated", workspace{}))
+	payload := []byte(`{"Projects":10}`)
+	assert.NoError(t, events.ValidateCtx(pro, "workspace.updated", payload))
+	assert.Error(t, events.ValidateCtx(free, "workspace.updated", payload))
+	pool := NewPool[workspace](v)
+	decode := func(w *workspace) error { w.Projects = 10; return nil }
+	handle := func(*workspace) error { return nil }
+	assert.NoError(t, pool.HandleCtx(pro, decode, handle))
+	assert.Error(t, pool.HandleCtx(free, decode, handle))
+
+	err = ValidateCtx(pro, ws)
+	assert.EqualError(t, err, `quota "max_projects": no QuotaProvider, see WithQuotaProvider`)
+}
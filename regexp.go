@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	resyntax "regexp/syntax"
+	"strconv"
+)
+
+// defaultRegexpInsts caps the size of the patterns accepted by the
+// isregexp rule without parameter, in instructions of their compiled
+// program.
+const defaultRegexpInsts = 1000
+
+// isRegexpRule requires a string compiling as a Go regular expression,
+// e.g. a user-supplied filter. Its compiled program may have at most the
+// number of instructions given by the parameter, e.g. isregexp:200, or
+// defaultRegexpInsts, so that patterns like "(a{1,100}){1,10}" which are
+// costly to match are rejected.
+var isRegexpRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		re, err := resyntax.Parse(val, resyntax.Perl)
+		if err != nil {
+			return false, nil
+		}
+		prog, err := resyntax.Compile(re.Simplify())
+		if err != nil {
+			return false, nil
+		}
+		return len(prog.Inst) <= p.compiled.(int), nil
+	},
+	compile: func(p param, _ reflect.Type) (any, error) {
+		if p.val == "" {
+			return defaultRegexpInsts, nil
+		}
+		n, err := strconv.Atoi(p.val)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("%w: isregexp expects a positive instruction count, got %q", ErrInvalidValidatorSyntax, p.val)
+		}
+		return n, nil
+	},
+	noParam: true,
+}
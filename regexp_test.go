@@ -0,0 +1,32 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRegexpRule(t *testing.T) {
+	for _, tt := range []struct {
+		val, tag string
+		ok       bool
+	}{
+		{`^[a-z]+-\d{2,4}$`, "isregexp", true},
+		{`(?i)error|warn`, "isregexp", true},
+		{``, "isregexp", true},
+		{`[a-z`, "isregexp", false},
+		{`a**`, "isregexp", false},
+		{`\p{Nope}`, "isregexp", false},
+		{`(a{1,100}){1,10}`, "isregexp", false},
+		{`a{1,50}`, "isregexp:50", false},
+		{`a{1,50}`, "isregexp:200", true},
+	} {
+		err := Var(tt.val, tt.tag)
+		if tt.ok {
+			assert.NoError(t, err, "%q %s", tt.val, tt.tag)
+		} else {
+			assert.Error(t, err, "%q %s", tt.val, tt.tag)
+		}
+	}
+	assert.ErrorIs(t, Var("x", "isregexp:0"), ErrInvalidValidatorSyntax)
+}
@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// RegisterRules registers rules for the fields of the struct type of
+// sample with the package default Validator.
+func RegisterRules(sample any, fields map[string]string) error {
+	return defaultValidator.RegisterRules(sample, fields)
+}
+
+// RegisterRules registers tags for fields of the struct type of sample,
+// which may be a pointer. Registered tags are layered over the struct tags
+// of the fields, as with WithTagKeys, and replace tags registered before
+// for the same fields.
+//
+// Fields may be promoted from embedded structs. When B embeds A, rules
+// registered on B for a field promoted from A are layered over those
+// registered on A, so that B specializes A: a rule of B replaces the rule
+// of A with the same name, and other rules of B are added. This holds
+// through any number of embedding levels, the outermost type winning.
+//
+// Registration applies to v, its parent and its children, and drops
+// their cached plans.
+func (v *Validator) RegisterRules(sample any, fields map[string]string) error {
+	t := reflect.TypeOf(sample)
+	if t == nil || indirectType(t).Kind() != reflect.Struct {
+		return ErrNotStruct
+	}
+	t = indirectType(t)
+	for name := range fields {
+		if _, ok := t.FieldByName(name); !ok {
+			return fmt.Errorf("register rules: %s has no field %q", t, name)
+		}
+	}
+	v.cache.register(t, fields)
+	return nil
+}
+
+// register stores the tags of fields of t and drops the cached plans.
+func (c *planCache) register(t reflect.Type, fields map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	registered := make(map[reflect.Type]map[string]string, len(c.registered)+1)
+	for typ, tags := range c.registered {
+		registered[typ] = tags
+	}
+	tags := make(map[string]string, len(registered[t])+len(fields))
+	for name, tag := range registered[t] {
+		tags[name] = tag
+	}
+	for name, tag := range fields {
+		tags[name] = tag
+	}
+	registered[t] = tags
+	c.registered = registered
+	c.gen++
+	c.clearLocked()
+}
+
+// promotedRules returns the tags of the fields of struct type t promoted
+// from its i-th field, an embedded struct, registered on t or inherited
+// from the types embedding t, in increasing order of precedence.
+func promotedRules(t reflect.Type, i int, registered map[string]string, overrides map[string][]string) map[string][]string {
+	if !t.Field(i).Anonymous {
+		return nil
+	}
+	var res map[string][]string
+	add := func(name, tag string) {
+		if sf, ok := t.FieldByName(name); ok && len(sf.Index) > 1 && sf.Index[0] == i {
+			if res == nil {
+				res = make(map[string][]string)
+			}
+			res[name] = append(res[name], tag)
+		}
+	}
+	for name, tag := range registered {
+		add(name, tag)
+	}
+	for name, tags := range overrides {
+		for _, tag := range tags {
+			add(name, tag)
+		}
+	}
+	return res
+}
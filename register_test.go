@@ -0,0 +1,91 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterRules(t *testing.T) {
+	type base struct {
+		Name  string `validate:"min:1"`
+		Email string
+	}
+	type user struct {
+		base
+		Role string
+	}
+	type admin struct {
+		*user
+		Level int
+	}
+	v := New()
+	assert.NoError(t, v.RegisterRules(base{}, map[string]string{"Name": "max:64", "Email": "min:3"}))
+	assert.NoError(t, v.RegisterRules(&user{}, map[string]string{"Name": "max:32", "Role": "in:user,guest"}))
+	assert.NoError(t, v.RegisterRules(admin{}, map[string]string{"Name": "max:8;len:5", "Role": "in:admin"}))
+
+	long := base{Name: "abcdefghijklmnopqrstuvwxyz0123456789", Email: "a@b"}
+	err := v.Validate(base{Email: "ab"})
+	assert.EqualError(t, err, `.Name: validation failed for "min" tag`+
+		`; .Email: validation failed for "min" tag`)
+	assert.NoError(t, v.Validate(base{Name: long.Name[:30], Email: "a@b"}))
+
+	// user specializes the max of base, keeping its min.
+	err = v.Validate(user{base: base{Email: "a@b"}, Role: "user"})
+	assert.EqualError(t, err, `.base.Name: validation failed for "min" tag`)
+	err = v.Validate(user{base: long, Role: "admin"})
+	assert.EqualError(t, err, `.base.Name: validation failed for "max" tag`+
+		`; .Role: validation failed for "in" tag`)
+
+	// admin specializes user in turn, through a pointer.
+	err = v.Validate(admin{user: &user{base: base{Name: "abcdefghi", Email: "a@b"}, Role: "user"}})
+	assert.EqualError(t, err, `.user.base.Name: validation failed for "max" tag`+
+		`; .user.base.Name: validation failed for "len" tag`+
+		`; .user.Role: validation failed for "in" tag`)
+	assert.NoError(t, v.Validate(admin{user: &user{base: base{Name: "alice", Email: "a@b"}, Role: "admin"}}))
+
+	// Registrations are shared with children, but not other validators.
+	assert.Error(t, v.Child().Validate(base{Name: "a", Email: "ab"}))
+	assert.NoError(t, New().Validate(base{Name: "a", Email: "ab"}))
+
+	assert.EqualError(t, v.RegisterRules(base{}, map[string]string{"Phone": "min:1"}),
+		`register rules: validate.base has no field "Phone"`)
+	assert.Equal(t, ErrNotStruct, v.RegisterRules(1, nil))
+	assert.Equal(t, ErrNotStruct, v.RegisterRules(nil, nil))
+}
+
+func TestRegisterRulesConcurrently(t *testing.T) {
+	type item struct {
+		Name string
+	}
+	v := New()
+	// A plan built before a registration is not cached after it.
+	key := planKey{typ: reflect.TypeOf(item{}), syntax: v.opts.syntax}
+	stale := &planEntry{key: key, plan: newStructPlan(key.typ, &v.opts, nil, nil), gen: v.cache.gen}
+	assert.NoError(t, v.RegisterRules(item{}, map[string]string{"Name": "min:1"}))
+	assert.Same(t, stale, v.cache.add(stale))
+	assert.Nil(t, v.cache.get(key))
+	assert.Error(t, v.Validate(item{}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = v.Validate(item{})
+				if i == 0 {
+					assert.NoError(t, v.RegisterRules(item{}, map[string]string{"Name": fmt.Sprintf("min:%d", j)}))
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	// The last registration holds, whichever plans were being built.
+	assert.NoError(t, v.Validate(item{Name: strings.Repeat("a", 99)}))
+	assert.Error(t, v.Validate(item{Name: strings.Repeat("a", 98)}))
+}
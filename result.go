@@ -0,0 +1,95 @@
+package validate
+
+// Result is the outcome of Check. It is an alternative to the error
+// returned by Validate for callers preferring not to inspect error types.
+type Result struct {
+	errs ValidationErrors
+	// err is set when validation could not be carried out.
+	err      error
+	outcomes map[string]Outcome
+	warnings ValidationErrors
+}
+
+// Outcome tells what happened to the rules of a field.
+type Outcome int
+
+const (
+	// NotEvaluated means the rules of the field were skipped, e.g. because
+	// of groups, partial validation or fail fast mode.
+	NotEvaluated Outcome = iota
+	// Passed means the field passed all its evaluated rules.
+	Passed
+	// Failed means the field failed at least one rule.
+	Failed
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Passed:
+		return "passed"
+	case Failed:
+		return "failed"
+	}
+	return "not evaluated"
+}
+
+// Valid reports whether the value passed all rules.
+func (r Result) Valid() bool {
+	return r.err == nil && len(r.errs) == 0
+}
+
+// Errors returns the failed rules. When validation could not be carried
+// out, e.g. because of an invalid tag, the cause is the only element.
+func (r Result) Errors() ValidationErrors {
+	if r.err != nil {
+		return ValidationErrors{{Err: r.err}}
+	}
+	return r.errs
+}
+
+// Warnings returns the failures of warning rules, like deprecated, and of
+// rules written with the warn modifier, e.g. "warn:max:100", which do not
+// make the value invalid.
+func (r Result) Warnings() ValidationErrors {
+	return r.warnings
+}
+
+// Field returns the failures of the field at path, e.g. ".Items[2].Name".
+func (r Result) Field(path string) []ValidationError {
+	var res []ValidationError
+	for _, err := range r.errs {
+		if err.Path == path {
+			res = append(res, err)
+		}
+	}
+	return res
+}
+
+// Err returns the error Validate would have returned.
+func (r Result) Err() error {
+	switch {
+	case r.err == ErrNotStruct, r.err == ErrMaxDepthExceeded:
+		return r.err
+	case r.err != nil:
+		return r.Errors()
+	case len(r.errs) > 0:
+		return r.errs
+	}
+	return nil
+}
+
+// Outcome returns the outcome of the rules of the field at path. Fields
+// without rules and fields never reached are NotEvaluated.
+func (r Result) Outcome(path string) Outcome {
+	return r.outcomes[path]
+}
+
+// Outcomes returns the outcome of every field having rules which was
+// reached during validation, keyed by path.
+func (r Result) Outcomes() map[string]Outcome {
+	res := make(map[string]Outcome, len(r.outcomes))
+	for path, o := range r.outcomes {
+		res[path] = o
+	}
+	return res
+}
@@ -0,0 +1,73 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	type item struct {
+		Name string `validate:"min:2;in:ab,cd"`
+	}
+	type order struct {
+		Items []item
+		Qty   int `validate:"min:1"`
+	}
+
+	res := Check(order{Items: []item{{"ab"}}, Qty: 1})
+	assert.True(t, res.Valid())
+	assert.Empty(t, res.Errors())
+	assert.NoError(t, res.Err())
+
+	res = Check(order{Items: []item{{"ab"}, {"x"}}})
+	assert.False(t, res.Valid())
+	assert.Len(t, res.Errors(), 3)
+	assert.Len(t, res.Field(".Items[1].Name"), 2)
+	assert.Len(t, res.Field(".Qty"), 1)
+	assert.Empty(t, res.Field(".Items[0].Name"))
+	assert.Equal(t, res.Errors(), res.Err())
+
+	res = Check(struct {
+		Qty int `validate:"min:x"`
+	}{})
+	assert.False(t, res.Valid())
+	assert.Equal(t, ValidationErrors{{Err: ErrInvalidValidatorSyntax}}, res.Errors())
+
+	res = Check(42)
+	assert.False(t, res.Valid())
+	assert.Equal(t, ErrNotStruct, res.Err())
+}
+
+func TestCheckOutcomes(t *testing.T) {
+	type form struct {
+		ID    int    `validate:"groups:update;min:1"`
+		Name  string `validate:"min:3"`
+		Email string `validate:"min:5"`
+		Age   int
+		Tags  []string `validate:"max:3"`
+	}
+	f := form{Name: "bob", Email: "x", Tags: []string{"a", "long"}}
+
+	res := Check(f)
+	assert.Equal(t, map[string]Outcome{
+		".ID":      NotEvaluated,
+		".Name":    Passed,
+		".Email":   Failed,
+		".Tags[0]": Passed,
+		".Tags[1]": Failed,
+	}, res.Outcomes())
+	assert.Equal(t, NotEvaluated, res.Outcome(".Age"))
+	assert.Equal(t, "failed", res.Outcome(".Email").String())
+
+	res = Check(f, WithPartial("Name"))
+	assert.Equal(t, Passed, res.Outcome(".Name"))
+	assert.Equal(t, NotEvaluated, res.Outcome(".Email"))
+	_, recorded := res.Outcomes()[".Email"]
+	assert.True(t, recorded)
+
+	res = Check(f, WithFailFast())
+	assert.Equal(t, Failed, res.Outcome(".Email"))
+	_, recorded = res.Outcomes()[".Tags[0]"]
+	assert.False(t, recorded)
+}
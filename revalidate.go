@@ -0,0 +1,74 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// ErrInvalidInterval is returned by Revalidate for intervals which are not
+// positive, which would revalidate values without pause.
+var ErrInvalidInterval = errors.New("revalidation interval must be positive")
+
+// Revalidation configures Revalidate.
+type Revalidation struct {
+	// Interval is the time between two validations. It must be positive.
+	Interval time.Duration
+	// Jitter, when positive, delays each validation by a random duration
+	// up to Jitter, so that values revalidated together spread their
+	// validations.
+	Jitter time.Duration
+	// OnFailure is called with the failures of a validation which the
+	// previous validation did not report. Failures are told apart by their
+	// error message.
+	OnFailure func(errs ValidationErrors)
+	// Options are passed to each validation.
+	Options []Option
+}
+
+// Revalidate revalidates a long-lived value with the package default
+// Validator.
+func Revalidate(ctx context.Context, value func() any, r Revalidation) error {
+	return defaultValidator.Revalidate(ctx, value, r)
+}
+
+// Revalidate validates the value returned by value now and then at every
+// interval until ctx is done, returning ctx.Err(). It suits configuration
+// and other values held in memory while rules change, e.g. with
+// RegisterRules: failures are reported once, when they appear. value is
+// called before each validation, so it may return the current version of
+// the value. Revalidate blocks; callers usually run it in a goroutine.
+func (v *Validator) Revalidate(ctx context.Context, value func() any, r Revalidation) error {
+	if r.Interval <= 0 {
+		return ErrInvalidInterval
+	}
+	seen := make(map[string]bool)
+	for {
+		errs := v.checkCtx(ctx, value(), r.Options, false).Errors()
+		current := make(map[string]bool, len(errs))
+		var fresh ValidationErrors
+		for _, err := range errs {
+			msg := err.Err.Error()
+			current[msg] = true
+			if !seen[msg] {
+				fresh = append(fresh, err)
+			}
+		}
+		seen = current
+		if len(fresh) > 0 && r.OnFailure != nil {
+			r.OnFailure(fresh)
+		}
+		delay := r.Interval
+		if r.Jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(r.Jitter)))
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package validate
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevalidate(t *testing.T) {
+	type config struct {
+		Workers int
+		Name    string
+	}
+	v := New()
+	cfg := &config{Workers: 8, Name: "svc"}
+	ctx, cancel := context.WithCancel(context.Background())
+	failures := make(chan ValidationErrors)
+	var runs int32
+	done := make(chan error)
+	go func() {
+		done <- v.Revalidate(ctx, func() any {
+			atomic.AddInt32(&runs, 1)
+			return cfg
+		}, Revalidation{
+			Interval: time.Millisecond,
+			Jitter:   time.Millisecond,
+			OnFailure: func(errs ValidationErrors) {
+				failures <- errs
+			},
+		})
+	}()
+
+	for atomic.LoadInt32(&runs) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	assert.NoError(t, v.RegisterRules(config{}, map[string]string{"Workers": "max:4"}))
+	errs := <-failures
+	assert.EqualError(t, errs, `.Workers: validation failed for "max" tag`)
+
+	// Failures already reported are not reported again.
+	start := atomic.LoadInt32(&runs)
+	for atomic.LoadInt32(&runs) < start+3 {
+		time.Sleep(time.Millisecond)
+	}
+	assert.NoError(t, v.RegisterRules(config{}, map[string]string{"Name": "len:2"}))
+	errs = <-failures
+	assert.EqualError(t, errs, `.Name: validation failed for "len" tag`)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+
+	value := func() any {
+		t.Fatal("revalidated without interval")
+		return cfg
+	}
+	assert.ErrorIs(t, v.Revalidate(context.Background(), value, Revalidation{}), ErrInvalidInterval)
+	assert.ErrorIs(t, Revalidate(context.Background(), value, Revalidation{Interval: -time.Second, Jitter: time.Second}), ErrInvalidInterval)
+}
@@ -0,0 +1,707 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type rule struct {
+	assertInt   func(val int64, p param) (bool, error)
+	assertUint  func(val uint64, p param) (bool, error)
+	assertFloat func(val float64, p param) (bool, error)
+	assertStr   func(val string, p param) (bool, error)
+	assertBool  func(val bool, p param) (bool, error)
+	assertTime  func(val time.Time, p param) (bool, error)
+	// assertNumber checks arbitrary-precision numbers through cmp, which
+	// compares them with a number given as a string. See NumberLike.
+	assertNumber func(cmp func(s string) (int, error), p param) (bool, error)
+	// assertStruct checks structs as a whole, like embedded structs.
+	assertStruct func(v reflect.Value, p param) (bool, error)
+	// assertSize checks the number of entries of a map.
+	assertSize func(n int64, p param) (bool, error)
+	// assertField is set for rules which need the struct holding the field.
+	// Such rules are evaluated once on the field rather than on each value
+	// nested in it.
+	assertField func(fl fieldLevel, p param) (bool, error)
+	// assertElems is set for rules checking the elements of a slice or an
+	// array together. Like field rules, they are evaluated once on the
+	// field, and return the indices of the failing elements.
+	assertElems func(v reflect.Value, p param) ([]int, error)
+	// explain, when set, details why a value failed the rule, e.g. by
+	// naming the other fields involved. fl.parent is the zero Value for
+	// rules which are not field rules.
+	explain func(fl fieldLevel, p param) string
+	// warn makes the failures of the rule warnings, reported by
+	// Result.Warnings, rather than errors.
+	warn bool
+	// compile, when set, prepares the parameter once per struct type.
+	// Its result is available to asserts as p.compiled. owner is nil for
+	// tags given to Var.
+	compile func(p param, owner reflect.Type) (any, error)
+	// noParam is set for rules which take no parameter.
+	noParam bool
+	// modify, when set, makes the rule a modifier: rather than checking the
+	// value, it transforms the value seen by the next rules of the tag.
+	modify func(v reflect.Value) (reflect.Value, error)
+	// normalize, when set, returns the normalized form of a string value
+	// passing the rule, which the next rules of the tag see like the result
+	// of a modifier. See WithNormalize.
+	normalize func(val string) (string, bool)
+	// suggest, when set, returns a close valid value for a value failing
+	// the rule. See WithSuggestions.
+	suggest func(v reflect.Value, p param) string
+	// presence is set for rules which check that a value is present. On
+	// optional values they only check that the optional is not empty,
+	// while other rules skip empty optionals.
+	presence bool
+}
+
+// fieldLevel is a field being validated along with the struct holding it
+// and the struct passed to Validate.
+type fieldLevel struct {
+	field  reflect.Value
+	parent reflect.Value
+	root   reflect.Value
+	// data is the call data set with WithData.
+	data map[string]any
+}
+
+// param is a rule parameter as written in a tag.
+type param struct {
+	val     string
+	listSep string
+	// elems holds the elements of val parsed at plan time, see parsed.
+	elems    []paramElem
+	compiled any
+	// opts and ctx hold the options and the context of the call
+	// evaluating the rule.
+	opts *options
+	ctx  context.Context
+}
+
+// compileLimit returns the compile function of the rules named name
+// whose optional parameter is a length limit, def by default.
+func compileLimit(name string, def int) func(p param, _ reflect.Type) (any, error) {
+	return func(p param, _ reflect.Type) (any, error) {
+		if p.val == "" {
+			return def, nil
+		}
+		n, err := strconv.Atoi(p.val)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%w: %s expects a length, got %q", ErrInvalidValidatorSyntax, name, p.val)
+		}
+		return n, nil
+	}
+}
+
+// list splits a set parameter like "a,b,c" into its elements.
+func (p param) list() []string {
+	return strings.Split(p.val, p.listSep)
+}
+
+// paramElem is an element of a set parameter, or a whole parameter,
+// parsed once so that rules do not parse their parameters on every
+// evaluation.
+type paramElem struct {
+	s                string
+	i                int64
+	u                uint64
+	f                float64
+	iErr, uErr, fErr error
+	// integer is set on integers, including those out of the range of
+	// int64 and uint64.
+	integer bool
+}
+
+func parseParamElem(s string) paramElem {
+	e := paramElem{s: s}
+	e.i, e.iErr = strconv.ParseInt(s, 10, 64)
+	e.u, e.uErr = strconv.ParseUint(s, 10, 64)
+	e.f, e.fErr = strconv.ParseFloat(s, 64)
+	if e.integer = e.iErr == nil || e.uErr == nil; !e.integer {
+		_, e.integer = new(big.Int).SetString(s, 10)
+	}
+	return e
+}
+
+// parsed returns p with its elements parsed, as done for the parameters
+// of tags when building plans.
+func (p param) parsed() param {
+	elems := p.list()
+	p.elems = make([]paramElem, len(elems))
+	for i, elem := range elems {
+		p.elems[i] = parseParamElem(elem)
+	}
+	return p
+}
+
+// values returns the parsed elements of a set parameter like "a,b,c".
+func (p param) values() []paramElem {
+	if p.elems == nil {
+		return p.parsed().elems
+	}
+	return p.elems
+}
+
+// value returns the parameter parsed as a whole.
+func (p param) value() paramElem {
+	if len(p.elems) == 1 {
+		return p.elems[0]
+	}
+	return parseParamElem(p.val)
+}
+
+// int parses an integer parameter.
+func (p param) int() (int64, error) {
+	e := p.value()
+	if e.iErr != nil {
+		return 0, ErrInvalidValidatorSyntax
+	}
+	return e.i, nil
+}
+
+// float parses a float parameter.
+func (p param) float() (float64, error) {
+	e := p.value()
+	if e.fErr != nil {
+		return 0, ErrInvalidValidatorSyntax
+	}
+	return e.f, nil
+}
+
+// cmpInt compares val with the integer parameter e, which may be out of
+// the range of int64, see OverflowPolicy.
+func (e paramElem) cmpInt(val int64, o *options) (int, error) {
+	if e.iErr != nil {
+		return overflowCmp(e.s, e.iErr, "int64", o)
+	}
+	switch {
+	case val < e.i:
+		return -1, nil
+	case val > e.i:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// cmpUint compares val with the integer parameter e, which may be negative
+// or out of the range of uint64, see OverflowPolicy.
+func (e paramElem) cmpUint(val uint64, o *options) (int, error) {
+	if strings.HasPrefix(e.s, "-") && (e.iErr == nil || errors.Is(e.iErr, strconv.ErrRange)) {
+		return 1, nil
+	}
+	if e.uErr != nil {
+		return overflowCmp(e.s, e.uErr, "uint64", o)
+	}
+	switch {
+	case val < e.u:
+		return -1, nil
+	case val > e.u:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// overflowCmp returns the result of comparing a value with the integer
+// parameter s which failed to parse in domain with err.
+func overflowCmp(s string, err error, domain string, o *options) (int, error) {
+	if !errors.Is(err, strconv.ErrRange) {
+		return 0, ErrInvalidValidatorSyntax
+	}
+	if o == nil || o.overflow == OverflowError {
+		return 0, fmt.Errorf("%w: parameter %s is out of the range of %s", ErrNumericOverflow, s, domain)
+	}
+	if strings.HasPrefix(s, "-") {
+		return 1, nil
+	}
+	return -1, nil
+}
+
+// groupsTag is a pseudo-rule restricting the rules of a tag to the listed groups.
+const groupsTag = "groups"
+
+// msgTag is a pseudo-rule giving the message of the failures of the other
+// rules of a tag, e.g. "min:8;msg:password must be at least 8 characters".
+// Its parameter runs to the end of the tag, so it comes last.
+const msgTag = "msg"
+
+// warnTag is a modifier making the failures of the rule it holds warnings,
+// e.g. "max:1000;warn:max:100", see Result.Warnings.
+const warnTag = "warn"
+
+// roleTag is a pseudo-rule holding a rule for a role, e.g.
+// "max:10;role:admin=max:1000", see WithRole.
+const roleTag = "role"
+
+// keysTag and valuesTag name the sections of a map tag holding a rule for
+// the keys and the values of the map, e.g. "min:1;keys:max:32;values:min:1".
+const (
+	keysTag   = "keys"
+	valuesTag = "values"
+)
+
+// omitemptyTag names the pseudo-rule skipping the rules following it when
+// the value is zero or absent.
+const omitemptyTag = "omitempty"
+
+// stringModifier returns a modifier rule applying fn to strings.
+func stringModifier(fn func(string) string) rule {
+	return rule{
+		modify: func(v reflect.Value) (reflect.Value, error) {
+			if v.Kind() != reflect.String {
+				return v, fmt.Errorf("unsupported type %s", v.Type())
+			}
+			return reflect.ValueOf(fn(v.String())), nil
+		},
+		noParam: true,
+	}
+}
+
+var rules = map[string]rule{
+	"trim":             stringModifier(strings.TrimSpace),
+	"lower":            stringModifier(strings.ToLower),
+	"upper":            stringModifier(strings.ToUpper),
+	"email":            normalizingRule(normalizeEmail),
+	"url":              normalizingRule(normalizeURL),
+	"phone":            normalizingRule(normalizePhone),
+	"quota":            quotaRule,
+	"money":            moneyRule,
+	"percent":          percentRule,
+	"bps":              bpsRule,
+	"geobounds":        geoboundsRule,
+	"within":           withinRule,
+	"eqfield":          crossFieldRule("eqfield", false, func(cmp int) bool { return cmp == 0 }),
+	"nefield":          crossFieldRule("nefield", false, func(cmp int) bool { return cmp != 0 }),
+	"gtfield":          crossFieldRule("gtfield", true, func(cmp int) bool { return cmp > 0 }),
+	"ltfield":          crossFieldRule("ltfield", true, func(cmp int) bool { return cmp < 0 }),
+	"required_if":      requiredIfRule("required_if", false),
+	"required_unless":  requiredIfRule("required_unless", true),
+	"required_with":    requiredWithRule("required_with", false),
+	"required_without": requiredWithRule("required_without", true),
+	"excluded_with":    excludedWithRule,
+	"excluded_if":      excludedIfRule,
+	"unique_by":        uniqueByRule,
+	"unit":             unitRule,
+	"useragent":        userAgentRule,
+	"accept_language":  acceptLanguageRule,
+	"envname":          envNameRule,
+	"envvalue":         envValueRule,
+	"noshellmeta":      noShellMetaRule,
+	"nopathtraversal":  noPathTraversalRule,
+	"sqlident":         sqlIdentRule,
+	"sqllike":          sqlLikeRule,
+	"gotemplate":       goTemplateRule,
+	"isregexp":         isRegexpRule,
+	"glob":             globRule,
+	"doublestar":       doubleStarRule,
+	"finite":           finiteRule,
+	"nonan":            noNaNRule,
+	"deprecated":       deprecatedRule,
+	"idempotency_key": idRule(func(o *options) *IDPolicy {
+		return o.idempotencyKeys
+	}, &DefaultIdempotencyKeyPolicy),
+	"requestid": idRule(func(o *options) *IDPolicy {
+		return o.requestIDs
+	}, &DefaultRequestIDPolicy),
+	"alpha": {
+		assertStr: func(val string, p param) (bool, error) {
+			for i := 0; i < len(val); i++ {
+				if c := val[i]; !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+					return false, nil
+				}
+			}
+			return val != "", nil
+		},
+		noParam: true,
+	},
+	"required": {
+		assertInt: func(val int64, p param) (bool, error) {
+			return val != 0, nil
+		},
+		assertUint: func(val uint64, p param) (bool, error) {
+			return val != 0, nil
+		},
+		assertFloat: func(val float64, p param) (bool, error) {
+			return val != 0, nil
+		},
+		assertStr: func(val string, p param) (bool, error) {
+			return val != "", nil
+		},
+		assertBool: func(val bool, p param) (bool, error) {
+			return val, nil
+		},
+		assertTime: func(val time.Time, p param) (bool, error) {
+			return !val.IsZero(), nil
+		},
+		assertSize: func(n int64, p param) (bool, error) {
+			return n != 0, nil
+		},
+		noParam:  true,
+		presence: true,
+		assertNumber: func(cmp func(string) (int, error), p param) (bool, error) {
+			c, err := cmp("0")
+			return c != 0, err
+		},
+		assertStruct: func(v reflect.Value, p param) (bool, error) {
+			return !v.IsZero(), nil
+		},
+	},
+	"len": {
+		assertInt: func(val int64, p param) (bool, error) {
+			return true, nil
+		},
+		assertUint: func(val uint64, p param) (bool, error) {
+			return true, nil
+		},
+		assertFloat: func(val float64, p param) (bool, error) {
+			return true, nil
+		},
+		assertStr: func(val string, p param) (bool, error) {
+			trueLen, err := p.int()
+			if err != nil {
+				return false, err
+			}
+			return strLen(val, p) == trueLen, nil
+		},
+		assertSize: func(n int64, p param) (bool, error) {
+			trueLen, err := p.int()
+			return n == trueLen, err
+		},
+	},
+	"in": {
+		assertInt: func(val int64, p param) (bool, error) {
+			for _, elem := range p.values() {
+				c, err := elem.cmpInt(val, p.opts)
+				if err != nil {
+					return false, err
+				}
+				if c == 0 {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		assertUint: func(val uint64, p param) (bool, error) {
+			for _, elem := range p.values() {
+				c, err := elem.cmpUint(val, p.opts)
+				if err != nil {
+					return false, err
+				}
+				if c == 0 {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		assertFloat: func(val float64, p param) (bool, error) {
+			for _, elem := range p.values() {
+				if elem.fErr != nil {
+					return false, ErrInvalidValidatorSyntax
+				}
+				if math.Abs(val-elem.f) <= p.opts.floatEpsilon {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		assertStr: func(val string, p param) (bool, error) {
+			return listed(val, p), nil
+		},
+		assertTime: func(val time.Time, p param) (bool, error) {
+			for _, elem := range p.values() {
+				t, err := parseTime(elem.s)
+				if err != nil {
+					return false, err
+				}
+				if val.Equal(t) {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		suggest: suggestIn,
+		assertNumber: func(cmp func(string) (int, error), p param) (bool, error) {
+			for _, elem := range p.values() {
+				c, err := cmp(elem.s)
+				if err != nil || c == 0 {
+					return c == 0, err
+				}
+			}
+			return false, nil
+		},
+	},
+	"eq": {
+		assertInt: func(val int64, p param) (bool, error) {
+			c, err := p.value().cmpInt(val, p.opts)
+			return c == 0, err
+		},
+		assertUint: func(val uint64, p param) (bool, error) {
+			c, err := p.value().cmpUint(val, p.opts)
+			return c == 0, err
+		},
+		assertFloat: func(val float64, p param) (bool, error) {
+			f, err := p.float()
+			return math.Abs(val-f) <= p.opts.floatEpsilon, err
+		},
+		assertStr: func(val string, p param) (bool, error) {
+			return val == p.val, nil
+		},
+		assertBool: func(val bool, p param) (bool, error) {
+			b, err := strconv.ParseBool(p.val)
+			if err != nil {
+				return false, ErrInvalidValidatorSyntax
+			}
+			return val == b, nil
+		},
+		assertNumber: func(cmp func(string) (int, error), p param) (bool, error) {
+			c, err := cmp(p.val)
+			return c == 0, err
+		},
+	},
+	"fixedwidth": {
+		assertStr: func(val string, p param) (bool, error) {
+			return p.compiled.(*fixedWidth).check(val), nil
+		},
+		compile: compileFixedWidth,
+	},
+	"expr": {
+		assertField: func(fl fieldLevel, p param) (bool, error) {
+			return p.compiled.(*exprProgram).eval(fl)
+		},
+		compile: func(p param, owner reflect.Type) (any, error) {
+			if owner == nil {
+				return nil, fmt.Errorf("%w: expr is only supported on struct fields", ErrInvalidValidatorSyntax)
+			}
+			return compileExpr(p.val, owner)
+		},
+	},
+	"min": {
+		assertInt: func(val int64, p param) (bool, error) {
+			c, err := p.value().cmpInt(val, p.opts)
+			return c >= 0, err
+		},
+		assertUint: func(val uint64, p param) (bool, error) {
+			c, err := p.value().cmpUint(val, p.opts)
+			return c >= 0, err
+		},
+		assertFloat: func(val float64, p param) (bool, error) {
+			min, err := p.float()
+			if err != nil {
+				return false, err
+			}
+			return val >= min, nil
+		},
+		assertStr: func(val string, p param) (bool, error) {
+			min, err := p.int()
+			if err != nil {
+				return false, err
+			}
+			return strLen(val, p) >= min, nil
+		},
+		assertTime: func(val time.Time, p param) (bool, error) {
+			min, err := p.time()
+			return !val.Before(min), err
+		},
+		assertSize: func(n int64, p param) (bool, error) {
+			min, err := p.int()
+			return n >= min, err
+		},
+		assertNumber: func(cmp func(string) (int, error), p param) (bool, error) {
+			c, err := cmp(p.val)
+			return c >= 0, err
+		},
+	},
+	"max": {
+		assertInt: func(val int64, p param) (bool, error) {
+			c, err := p.value().cmpInt(val, p.opts)
+			return c <= 0, err
+		},
+		assertUint: func(val uint64, p param) (bool, error) {
+			c, err := p.value().cmpUint(val, p.opts)
+			return c <= 0, err
+		},
+		assertFloat: func(val float64, p param) (bool, error) {
+			max, err := p.float()
+			if err != nil {
+				return false, err
+			}
+			return val <= max, nil
+		},
+		assertStr: func(val string, p param) (bool, error) {
+			max, err := p.int()
+			if err != nil {
+				return false, err
+			}
+			return strLen(val, p) <= max, nil
+		},
+		assertTime: func(val time.Time, p param) (bool, error) {
+			max, err := p.time()
+			return !val.After(max), err
+		},
+		assertSize: func(n int64, p param) (bool, error) {
+			max, err := p.int()
+			return n <= max, err
+		},
+		assertNumber: func(cmp func(string) (int, error), p param) (bool, error) {
+			c, err := cmp(p.val)
+			return c <= 0, err
+		},
+	},
+	"gt": {
+		assertInt: func(val int64, p param) (bool, error) {
+			c, err := p.value().cmpInt(val, p.opts)
+			return c > 0 && err == nil, err
+		},
+		assertUint: func(val uint64, p param) (bool, error) {
+			c, err := p.value().cmpUint(val, p.opts)
+			return c > 0 && err == nil, err
+		},
+		assertFloat: func(val float64, p param) (bool, error) {
+			bound, err := p.float()
+			if err != nil {
+				return false, err
+			}
+			return val > bound, nil
+		},
+		assertStr: func(val string, p param) (bool, error) {
+			bound, err := p.int()
+			if err != nil {
+				return false, err
+			}
+			return strLen(val, p) > bound, nil
+		},
+		assertTime: func(val time.Time, p param) (bool, error) {
+			bound, err := p.time()
+			return val.After(bound) && err == nil, err
+		},
+		assertSize: func(n int64, p param) (bool, error) {
+			bound, err := p.int()
+			return n > bound && err == nil, err
+		},
+		assertNumber: func(cmp func(string) (int, error), p param) (bool, error) {
+			c, err := cmp(p.val)
+			return c > 0 && err == nil, err
+		},
+	},
+	"lt": {
+		assertInt: func(val int64, p param) (bool, error) {
+			c, err := p.value().cmpInt(val, p.opts)
+			return c < 0 && err == nil, err
+		},
+		assertUint: func(val uint64, p param) (bool, error) {
+			c, err := p.value().cmpUint(val, p.opts)
+			return c < 0 && err == nil, err
+		},
+		assertFloat: func(val float64, p param) (bool, error) {
+			bound, err := p.float()
+			if err != nil {
+				return false, err
+			}
+			return val < bound, nil
+		},
+		assertStr: func(val string, p param) (bool, error) {
+			bound, err := p.int()
+			if err != nil {
+				return false, err
+			}
+			return strLen(val, p) < bound, nil
+		},
+		assertTime: func(val time.Time, p param) (bool, error) {
+			bound, err := p.time()
+			return val.Before(bound) && err == nil, err
+		},
+		assertSize: func(n int64, p param) (bool, error) {
+			bound, err := p.int()
+			return n < bound && err == nil, err
+		},
+		assertNumber: func(cmp func(string) (int, error), p param) (bool, error) {
+			c, err := cmp(p.val)
+			return c < 0 && err == nil, err
+		},
+	},
+}
+
+// strLen returns the length of string val checked by the length rules: its
+// number of bytes, or of runes with WithPlaygroundSyntax, like
+// go-playground/validator.
+func strLen(val string, p param) int64 {
+	if p.opts != nil && p.opts.playground {
+		return int64(utf8.RuneCountInString(val))
+	}
+	return int64(len(val))
+}
+
+// Validate applies the rule to a leaf value, dispatching on its kind so
+// that all integer widths and defined types like "type Age int" validate
+// like their underlying types.
+func (r *rule) Validate(tagVal param, v reflect.Value) (res bool, err error) {
+	if len(tagVal.val) == 0 && !r.noParam {
+		return false, nil
+	}
+	if v.Type() == jsonNumberType {
+		if v, err = jsonNumber(v, tagVal); err != nil {
+			return false, err
+		}
+	}
+	if cmp, ok := numberCmp(v); ok && r.assertNumber != nil {
+		return r.assertNumber(cmp, tagVal)
+	}
+	if v.Type() == durationType && len(tagVal.val) > 0 {
+		if tagVal, err = tagVal.durations(); err != nil {
+			return false, err
+		}
+	}
+	switch {
+	case isIntKind(v.Kind()) && r.assertInt != nil:
+		return r.assertInt(v.Int(), tagVal)
+	case isUintKind(v.Kind()) && r.assertUint != nil:
+		return r.assertUint(v.Uint(), tagVal)
+	case isFloatKind(v.Kind()) && r.assertFloat != nil:
+		return r.assertFloat(v.Float(), tagVal)
+	case v.Kind() == reflect.String && r.assertStr != nil:
+		return r.assertStr(v.String(), tagVal)
+	case v.Kind() == reflect.Bool && r.assertBool != nil:
+		return r.assertBool(v.Bool(), tagVal)
+	case v.Type() == timeType && r.assertTime != nil && v.CanInterface():
+		return r.assertTime(v.Interface().(time.Time), tagVal)
+	case v.Kind() == reflect.Struct && r.assertStruct != nil:
+		return r.assertStruct(v, tagVal)
+	case v.Kind() == reflect.Map && r.assertSize != nil:
+		return r.assertSize(int64(v.Len()), tagVal)
+	}
+	if s, ok, err := text(v); ok && r.assertStr != nil {
+		if err != nil {
+			return false, err
+		}
+		return r.assertStr(s, tagVal)
+	}
+	return false, fmt.Errorf("unsupported type %s", v.Type())
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUintKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
@@ -0,0 +1,208 @@
+package validate
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegerKinds(t *testing.T) {
+	type ints struct {
+		I8   int8    `validate:"min:-5;max:5"`
+		I16  int16   `validate:"in:-300,300"`
+		I32  int32   `validate:"required"`
+		I64  int64   `validate:"max:9223372036854775807;min:-9223372036854775808"`
+		U    uint    `validate:"min:-1;max:10"`
+		U8   uint8   `validate:"in:-1,255"`
+		U16  uint16  `validate:"len:3"`
+		U32  uint32  `validate:"min:1"`
+		U64  uint64  `validate:"min:18446744073709551615"`
+		Uptr uintptr `validate:"max:0"`
+	}
+	valid := ints{I8: -5, I16: 300, I32: 1, I64: math.MinInt64, U: 10, U8: 255, U32: 1, U64: math.MaxUint64}
+	assert.NoError(t, Validate(valid))
+
+	invalid := ints{I8: 6, I16: 30, U: 11, U8: 1, U64: 1, Uptr: 1}
+	err := Validate(invalid)
+	assert.Len(t, err.(ValidationErrors), 8)
+
+	err = Validate(struct {
+		U uint `validate:"min:1.5"`
+	}{})
+	assert.Equal(t, ValidationErrors{{Err: ErrInvalidValidatorSyntax}}, err)
+}
+
+func TestNamedTypes(t *testing.T) {
+	type (
+		Age     int
+		Email   string
+		Score   float32
+		Flag    bool
+		Emails  []Email
+		Tags    map[Email]Age
+		Quota   uint16
+		PtrAge  *Age
+		Ratings [2]Score
+	)
+	type profile struct {
+		Age     Age     `validate:"min:18;max:130"`
+		Email   Email   `validate:"min:3;max:64"`
+		Score   Score   `validate:"in:0.5,1"`
+		Active  Flag    `validate:"eq:true"`
+		Aliases Emails  `validate:"len:5"`
+		Tags    Tags    `validate:"keys:min:1;values:min:1"`
+		Quota   Quota   `validate:"max:100"`
+		Parent  PtrAge  `validate:"min:36"`
+		Ratings Ratings `validate:"max:1"`
+	}
+	parent := Age(40)
+	assert.NoError(t, Validate(profile{
+		Age: 30, Email: "a@b.c", Score: 0.5, Active: true, Aliases: Emails{"a@b.c"},
+		Tags: Tags{"x": 1}, Quota: 100, Parent: &parent, Ratings: Ratings{1, 0.5},
+	}))
+
+	young := Age(20)
+	err := Validate(profile{
+		Age: 12, Email: "ab", Score: 0.75, Aliases: Emails{"ab"},
+		Tags: Tags{"": 0}, Quota: 101, Parent: &young, Ratings: Ratings{2},
+	})
+	assert.EqualError(t, err, `.Age: validation failed for "min" tag`+
+		`; .Email: validation failed for "min" tag`+
+		`; .Score: validation failed for "in" tag`+
+		`; .Active: validation failed for "eq" tag`+
+		`; .Aliases[0]: validation failed for "len" tag`+
+		`; .Tags[key=""]: validation failed for "min" tag`+
+		`; .Tags[""]: validation failed for "min" tag`+
+		`; .Quota: validation failed for "max" tag`+
+		`; .Parent: validation failed for "min" tag`+
+		`; .Ratings[0]: validation failed for "max" tag`)
+}
+
+func TestFloatKinds(t *testing.T) {
+	type price struct {
+		Amount   float64 `validate:"min:0.01;max:999.99"`
+		Discount float32 `validate:"in:0,0.1,0.25"`
+		Rate     float64 `validate:"required;max:1e-2"`
+	}
+	assert.NoError(t, Validate(price{Amount: 0.01, Discount: 0.25, Rate: 0.005}))
+
+	err := Validate(price{Amount: 1000, Discount: 0.1, Rate: 0})
+	assert.Len(t, err.(ValidationErrors), 3, "float32(0.1) is not exactly 0.1")
+
+	err = Validate(price{Amount: 1, Discount: 0.1, Rate: 0.001}, WithFloatEpsilon(1e-6))
+	assert.NoError(t, err)
+
+	err = Validate(struct {
+		F float64 `validate:"min:abc"`
+	}{})
+	assert.Equal(t, ValidationErrors{{Err: ErrInvalidValidatorSyntax}}, err)
+}
+
+func TestStrictBounds(t *testing.T) {
+	type order struct {
+		Qty   int     `validate:"gt:0;lt:100"`
+		Units uint    `validate:"gt:1"`
+		Price float64 `validate:"gt:0;lt:1e6"`
+		Code  string  `validate:"gt:2;lt:5"`
+	}
+	assert.NoError(t, Validate(order{Qty: 1, Units: 2, Price: 0.5, Code: "abc"}))
+	err := Validate(order{Qty: 100, Units: 1, Price: 0, Code: "ab"})
+	assert.EqualError(t, err, `.Qty: validation failed for "lt" tag`+
+		`; .Units: validation failed for "gt" tag`+
+		`; .Price: validation failed for "gt" tag`+
+		`; .Code: validation failed for "gt" tag`)
+
+	err = Validate(struct {
+		Above float64 `validate:"gt:0"`
+		Below float64 `validate:"lt:1e6"`
+	}{math.NaN(), math.NaN()})
+	assert.EqualError(t, err, `.Above: validation failed for "gt" tag`+
+		`; .Below: validation failed for "lt" tag`)
+
+	err = Validate(struct {
+		F float64 `validate:"gt:abc"`
+	}{})
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+}
+
+func TestBoolAndEq(t *testing.T) {
+	type signup struct {
+		TermsAccepted bool    `validate:"eq:true"`
+		Newsletter    bool    `validate:"eq:false"`
+		Confirmed     bool    `validate:"required"`
+		Plan          string  `validate:"eq:pro"`
+		Seats         int     `validate:"eq:-1"`
+		Quota         uint    `validate:"eq:10"`
+		Ratio         float64 `validate:"eq:0.5"`
+	}
+	assert.NoError(t, Validate(signup{true, false, true, "pro", -1, 10, 0.5}))
+
+	err := Validate(signup{})
+	assert.Len(t, err.(ValidationErrors), 6)
+
+	err = Validate(struct {
+		B bool `validate:"eq:yes"`
+	}{})
+	assert.Equal(t, ValidationErrors{{Err: ErrInvalidValidatorSyntax}}, err)
+
+	err = Validate(struct {
+		B bool `validate:"min:1"`
+	}{})
+	assert.EqualError(t, err, "unsupported type bool")
+}
+
+func TestModifiers(t *testing.T) {
+	type user struct {
+		Name  string `validate:"trim|lower|min:3|alpha"`
+		Code  string `validate:"upper|in:AB,CD"`
+		Title string `validate:"trim;len:3"`
+	}
+	u := user{Name: "  Bob ", Code: "ab", Title: " Mrs "}
+	assert.NoError(t, Validate(u))
+	assert.Equal(t, "  Bob ", u.Name, "modifiers must not change the struct")
+
+	err := Validate(user{Name: " B2 ", Code: "ef", Title: "Mrs"})
+	assert.Equal(t, ValidationErrors{
+		{Err: errors.New(`.Name: validation failed for "min" tag`),
+			StructField: "Name", Path: ".Name", Rule: "min", Param: "3", Code: "VAL_MIN", Value: "b2"},
+		{Err: errors.New(`.Name: validation failed for "alpha" tag`),
+			StructField: "Name", Path: ".Name", Rule: "alpha", Code: "VAL_ALPHA", Value: "b2"},
+		{Err: errors.New(`.Code: validation failed for "in" tag`),
+			StructField: "Code", Path: ".Code", Rule: "in", Param: "AB,CD", Code: "VAL_IN", Value: "EF"},
+	}, err)
+
+	assert.EqualError(t, Var(3, "trim"), "unsupported type int")
+	assert.NoError(t, Var("Go", "alpha"))
+	assert.Error(t, Var("", "alpha"))
+}
+
+func TestParsedParams(t *testing.T) {
+	o := defaultOptions()
+	tag := newFieldTag("in:1,-2,x;max:10;fixedwidth:3", nil, &o)
+	assert.NoError(t, tag.err)
+	in := tag.rules[0].param
+	if assert.Len(t, in.elems, 3) {
+		assert.Equal(t, int64(-2), in.elems[1].i)
+		assert.Error(t, in.elems[2].iErr)
+		assert.True(t, in.elems[1].integer)
+		assert.False(t, in.elems[2].integer)
+	}
+	assert.True(t, parseParamElem("123456789012345678901234567890").integer)
+	assert.False(t, parseParamElem("1.5").integer)
+	assert.Len(t, tag.rules[1].param.elems, 1)
+	assert.Nil(t, tag.rules[2].param.elems, "compiled parameters are not parsed")
+
+	in.opts = &o
+	allocs := testing.AllocsPerRun(100, func() {
+		ok, err := rules["in"].assertInt(-2, in)
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		ok, _ = rules["in"].assertStr("x", in)
+		assert.True(t, ok)
+		ok, _ = rules["max"].assertUint(10, tag.rules[1].param)
+		assert.True(t, ok)
+	})
+	assert.Zero(t, allocs, "parsed parameters are not parsed again")
+}
@@ -0,0 +1,78 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxSQLIdentLen is the longest identifier accepted by sqlident: the limit
+// of PostgreSQL, lower than the one of MySQL and SQL Server.
+const maxSQLIdentLen = 63
+
+// sqlReserved holds the keywords reserved by the SQL standard and the
+// common databases, which cannot be used as unquoted identifiers.
+var sqlReserved = setOf(strings.Fields(`
+	all alter and any as asc between by case cast check column constraint
+	create cross current_date current_time current_timestamp current_user
+	database default delete desc distinct drop else end except exists false
+	fetch for foreign from full grant group having in index inner insert
+	intersect into is join key leading left like limit natural not null
+	offset on or order outer primary references revoke right rows select
+	session_user set some table then to trailing true union unique update
+	user using values view when where with`))
+
+// sqlIdent reports whether val can be used unquoted as an SQL identifier:
+// ASCII letters, digits and underscores, not starting with a digit, not a
+// reserved keyword and at most maxSQLIdentLen bytes long.
+func sqlIdent(val string) bool {
+	if len(val) > maxSQLIdentLen || !envName(val) {
+		return false
+	}
+	return !sqlReserved[strings.ToLower(val)]
+}
+
+var sqlIdentRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		return sqlIdent(val), nil
+	},
+	noParam: true,
+}
+
+// sqlLikeRule requires a LIKE pattern whose escape character, given by the
+// parameter or else a backslash, only escapes the % and _ wildcards and
+// itself, so that the pattern means the same with every database. NUL
+// bytes are rejected.
+var sqlLikeRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		esc := p.compiled.(byte)
+		for i := 0; i < len(val); i++ {
+			switch val[i] {
+			case 0:
+				return false, nil
+			case esc:
+				i++
+				if i == len(val) || val[i] != '%' && val[i] != '_' && val[i] != esc {
+					return false, nil
+				}
+			}
+		}
+		return true, nil
+	},
+	compile: func(p param, _ reflect.Type) (any, error) {
+		return p.escapeChar()
+	},
+	noParam: true,
+}
+
+// escapeChar returns the escape character of a LIKE pattern given by p.
+func (p param) escapeChar() (byte, error) {
+	if p.val == "" {
+		return '\\', nil
+	}
+	if r, size := utf8.DecodeRuneInString(p.val); size != len(p.val) || r >= utf8.RuneSelf || r == '%' || r == '_' {
+		return 0, fmt.Errorf("%w: sqllike expects a single ASCII escape character other than %% and _, got %q", ErrInvalidValidatorSyntax, p.val)
+	}
+	return p.val[0], nil
+}
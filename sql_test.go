@@ -0,0 +1,46 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLRules(t *testing.T) {
+	for _, tt := range []struct {
+		val, tag string
+		ok       bool
+	}{
+		{"users", "sqlident", true},
+		{"_created_at2", "sqlident", true},
+		{"OrderItems", "sqlident", true},
+		{"2fa", "sqlident", false},
+		{"user-name", "sqlident", false},
+		{"users; drop table x", "sqlident", false},
+		{"public.users", "sqlident", false},
+		{"select", "sqlident", false},
+		{"Order", "sqlident", false},
+		{"", "sqlident", false},
+		{strings.Repeat("a", 63), "sqlident", true},
+		{strings.Repeat("a", 64), "sqlident", false},
+		{"john%", "sqllike", true},
+		{`100\%`, "sqllike", true},
+		{`a\_b\\c`, "sqllike", true},
+		{`trailing\`, "sqllike", false},
+		{`\d+`, "sqllike", false},
+		{"nul\x00", "sqllike", false},
+		{"50!%", "sqllike:!", true},
+		{`back\slash`, "sqllike:!", true},
+		{"bang!x", "sqllike:!", false},
+	} {
+		err := Var(tt.val, tt.tag)
+		if tt.ok {
+			assert.NoError(t, err, "%q %s", tt.val, tt.tag)
+		} else {
+			assert.Error(t, err, "%q %s", tt.val, tt.tag)
+		}
+	}
+	assert.ErrorIs(t, Var("x", "sqllike:%"), ErrInvalidValidatorSyntax)
+	assert.ErrorIs(t, Var("x", "sqllike:ab"), ErrInvalidValidatorSyntax)
+}
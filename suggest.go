@@ -0,0 +1,63 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// WithSuggestions makes failures of the in rule on strings carry the
+// closest allowed value in FieldError.Suggestion, e.g. "green" for "gren"
+// with "in:red,green,blue", which their messages propose: `.Color:
+// validation failed for "in" tag: did you mean "green"?`.
+func WithSuggestions() Option {
+	return func(o *options) {
+		o.suggestions = true
+	}
+}
+
+// suggestIn returns the element of the in parameter closest to v, or ""
+// when none is close enough: differing by at most half of its characters,
+// ignoring case.
+func suggestIn(v reflect.Value, p param) string {
+	if v.Kind() != reflect.String {
+		return ""
+	}
+	val := strings.ToLower(v.String())
+	best, bestDist := "", -1
+	for _, elem := range p.values() {
+		d := levenshtein(val, strings.ToLower(elem.s))
+		if d*2 <= utf8.RuneCountInString(elem.s) && (bestDist < 0 || d < bestDist) {
+			best, bestDist = elem.s, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b, in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,55 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestions(t *testing.T) {
+	type paint struct {
+		Color string   `validate:"in:red,green,blue"`
+		Tags  []string `validate:"in:matte,gloss"`
+		Coats int      `validate:"in:1,2"`
+	}
+	p := paint{Color: "gren", Tags: []string{"GLOSS", "satin", "mate"}, Coats: 3}
+
+	errs := Validate(p, WithSuggestions()).(ValidationErrors)
+	var suggestions []string
+	for _, err := range errs {
+		suggestions = append(suggestions, err.Suggestion)
+	}
+	assert.Equal(t, []string{"green", "gloss", "", "matte", ""}, suggestions)
+
+	assert.EqualError(t, errs[0], `.Color: validation failed for "in" tag: did you mean "green"?`)
+	assert.EqualError(t, errs[2], `.Tags[1]: validation failed for "in" tag`)
+	translated := errs.Translate("en")
+	assert.EqualError(t, translated[0], `Color must be one of red,green,blue, did you mean "green"?`)
+	assert.EqualError(t, translated[2], `Tags[1] must be one of matte,gloss`)
+
+	errs = Validate(p).(ValidationErrors)
+	assert.Empty(t, errs[0].Suggestion)
+	assert.EqualError(t, errs[0], `.Color: validation failed for "in" tag`)
+
+	errs = Validate(struct {
+		Size string `validate:"oneof=small large"`
+	}{"smal"}, WithPlaygroundSyntax(), WithSuggestions()).(ValidationErrors)
+	assert.Equal(t, "small", errs[0].Suggestion)
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"gren", "green", 1},
+		{"héllo", "hello", 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, levenshtein(tt.a, tt.b), "%q %q", tt.a, tt.b)
+		assert.Equal(t, tt.want, levenshtein(tt.b, tt.a), "%q %q", tt.b, tt.a)
+	}
+}
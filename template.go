@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"reflect"
+	"text/template/parse"
+)
+
+// goTemplateRule requires a string parsing as a text/template. Its
+// optional parameter lists the functions the template may call, builtins
+// like printf included, e.g. "gotemplate:upper,lower,printf"; without it
+// any function may be called.
+var goTemplateRule = rule{
+	assertStr: func(val string, p param) (bool, error) {
+		trees := make(map[string]*parse.Tree)
+		t := parse.New("gotemplate")
+		t.Mode = parse.SkipFuncCheck
+		if _, err := t.Parse(val, "", "", trees); err != nil {
+			return false, nil
+		}
+		allowed, _ := p.compiled.(map[string]bool)
+		if allowed == nil {
+			return true, nil
+		}
+		for _, tree := range trees {
+			if !callsAllowed(tree.Root, allowed) {
+				return false, nil
+			}
+		}
+		return true, nil
+	},
+	compile: func(p param, _ reflect.Type) (any, error) {
+		if p.val == "" {
+			return nil, nil
+		}
+		return setOf(p.list()), nil
+	},
+	noParam: true,
+}
+
+// callsAllowed reports whether the functions called under node are all
+// allowed.
+func callsAllowed(node parse.Node, allowed map[string]bool) bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return true
+		}
+		for _, child := range n.Nodes {
+			if !callsAllowed(child, allowed) {
+				return false
+			}
+		}
+	case *parse.ActionNode:
+		return callsAllowed(n.Pipe, allowed)
+	case *parse.IfNode:
+		return callsAllowed(&n.BranchNode, allowed)
+	case *parse.RangeNode:
+		return callsAllowed(&n.BranchNode, allowed)
+	case *parse.WithNode:
+		return callsAllowed(&n.BranchNode, allowed)
+	case *parse.BranchNode:
+		return callsAllowed(n.Pipe, allowed) && callsAllowed(n.List, allowed) && callsAllowed(n.ElseList, allowed)
+	case *parse.TemplateNode:
+		return callsAllowed(n.Pipe, allowed)
+	case *parse.PipeNode:
+		if n == nil {
+			return true
+		}
+		for _, cmd := range n.Cmds {
+			if !callsAllowed(cmd, allowed) {
+				return false
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if !callsAllowed(arg, allowed) {
+				return false
+			}
+		}
+	case *parse.ChainNode:
+		return callsAllowed(n.Node, allowed)
+	case *parse.IdentifierNode:
+		return allowed[n.Ident]
+	}
+	return true
+}
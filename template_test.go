@@ -0,0 +1,35 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoTemplateRule(t *testing.T) {
+	for _, tt := range []struct {
+		val, tag string
+		ok       bool
+	}{
+		{"Hello {{.Name}}", "gotemplate", true},
+		{"{{if .Admin}}root{{else}}{{.Name | upper}}{{end}}", "gotemplate", true},
+		{"{{define \"x\"}}{{.}}{{end}}{{template \"x\" .}}", "gotemplate", true},
+		{"plain text", "gotemplate", true},
+		{"{{.Name", "gotemplate", false},
+		{"{{if .A}}unterminated", "gotemplate", false},
+		{"{{end}}", "gotemplate", false},
+		{"{{.Name | upper}}", "gotemplate:upper,lower", true},
+		{"{{range .Items}}{{lower .}}{{end}}", "gotemplate:upper,lower", true},
+		{"{{printf \"%d\" .N}}", "gotemplate:upper,lower", false},
+		{"{{if .A}}{{call .F}}{{end}}", "gotemplate:upper", false},
+		{"{{with $x := env \"HOME\"}}{{$x}}{{end}}", "gotemplate:upper", false},
+		{"{{define \"x\"}}{{exec .}}{{end}}", "gotemplate:upper", false},
+	} {
+		err := Var(tt.val, tt.tag)
+		if tt.ok {
+			assert.NoError(t, err, "%q %s", tt.val, tt.tag)
+		} else {
+			assert.Error(t, err, "%q %s", tt.val, tt.tag)
+		}
+	}
+}
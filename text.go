@@ -0,0 +1,56 @@
+package validate
+
+import (
+	"encoding"
+	"reflect"
+	"strings"
+)
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// isScalar reports whether values of type t are validated as a single
+// value even when they are structs, arrays, slices or maps: times,
+// numbers, and types with a text form like netip.Addr or uuid.UUID.
+// Structs with a text form whose fields have rules under the tag keys of
+// o are validated field by field like other structs.
+func isScalar(t reflect.Type, o *options) bool {
+	return t == timeType || isNumber(t) || isTextMarshaler(t) && !hasFieldRules(t, o)
+}
+
+// hasFieldRules reports whether t is a struct with a field tagged with one
+// of the tag keys of o.
+func hasFieldRules(t reflect.Type, o *options) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		for _, key := range strings.Fields(o.tagKeys) {
+			if _, ok := t.Field(i).Tag.Lookup(key); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isTextMarshaler(t reflect.Type) bool {
+	return t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// text returns the text form of v when it implements
+// encoding.TextMarshaler. Numbers are compared by value instead, so they
+// are never reported as text.
+func text(v reflect.Value) (s string, ok bool, err error) {
+	if !v.CanInterface() || isNumber(v.Type()) || !isTextMarshaler(v.Type()) {
+		return "", false, nil
+	}
+	if v.CanAddr() {
+		v = v.Addr()
+	} else {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p
+	}
+	b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+	return string(b), true, err
+}
@@ -0,0 +1,73 @@
+package validate
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testUUID [16]byte
+
+func (u testUUID) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(u[:])), nil
+}
+
+type testBadText struct{}
+
+func (*testBadText) MarshalText() ([]byte, error) {
+	return nil, errors.New("bad text")
+}
+
+// testMoney has a text form and rules on its fields.
+type testMoney struct {
+	Currency string `validate:"len:3"`
+	Cents    int64  `validate:"min:0"`
+}
+
+func (m testMoney) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d %s", m.Cents, m.Currency)), nil
+}
+
+func TestTextMarshalers(t *testing.T) {
+	type S struct {
+		Addr   netip.Addr   `validate:"required:;in:10.0.0.1,10.0.0.2"`
+		Prefix netip.Prefix `validate:"max:16"`
+		ID     testUUID     `validate:"len:32"`
+		IDs    []testUUID   `validate:"len:32"`
+		At     time.Time    `validate:"len:20"`
+	}
+	ok := S{
+		Addr:   netip.MustParseAddr("10.0.0.2"),
+		Prefix: netip.MustParsePrefix("192.168.0.0/16"),
+		IDs:    []testUUID{{1}},
+		At:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	assert.NoError(t, Validate(ok))
+
+	bad := ok
+	bad.Addr = netip.MustParseAddr("10.0.0.3")
+	bad.Prefix = netip.MustParsePrefix("2001:db8:1234::/48")
+	bad.At = bad.At.Add(time.Millisecond)
+	assert.EqualError(t, Validate(bad), `.Addr: validation failed for "in" tag`+
+		`; .Prefix: validation failed for "max" tag`+
+		`; .At: validation failed for "len" tag`)
+
+	assert.EqualError(t, Validate(S{}), `.Addr: validation failed for "required" tag`+
+		`; .Addr: validation failed for "in" tag`)
+
+	assert.NoError(t, Var(netip.MustParseAddr("::1"), "len:3"))
+	assert.EqualError(t, Var(testBadText{}, "len:3"), "bad text")
+
+	// The rules of the fields of testMoney are not skipped for its text form.
+	type order struct {
+		Price testMoney
+	}
+	assert.NoError(t, Validate(order{testMoney{"EUR", 100}}))
+	assert.EqualError(t, Validate(order{testMoney{"EURO", -1}}), `.Price.Currency: validation failed for "len" tag`+
+		`; .Price.Cents: validation failed for "min" tag`)
+}
@@ -0,0 +1,57 @@
+package validate
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// time parses a time parameter: an RFC 3339 timestamp like
+// "2020-01-01T00:00:00Z", or "now" optionally followed by a signed
+// duration, like "now-24h".
+func (p param) time() (time.Time, error) {
+	return parseTime(p.val)
+}
+
+func parseTime(s string) (time.Time, error) {
+	if rest, ok := strings.CutPrefix(s, "now"); ok {
+		now := time.Now()
+		if rest == "" {
+			return now, nil
+		}
+		if rest[0] != '+' && rest[0] != '-' {
+			return time.Time{}, ErrInvalidValidatorSyntax
+		}
+		d, err := time.ParseDuration(rest)
+		if err != nil {
+			return time.Time{}, ErrInvalidValidatorSyntax
+		}
+		return now.Add(d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, ErrInvalidValidatorSyntax
+	}
+	return t, nil
+}
+
+// durations rewrites the duration parameter p, like "1s" or "1m,5m", as
+// nanoseconds so that integer rules apply to time.Duration values.
+func (p param) durations() (param, error) {
+	elems := p.list()
+	for i, elem := range elems {
+		d, err := time.ParseDuration(elem)
+		if err != nil {
+			return p, ErrInvalidValidatorSyntax
+		}
+		elems[i] = strconv.FormatInt(int64(d), 10)
+	}
+	p.val = strings.Join(elems, p.listSep)
+	return p.parsed(), nil
+}
@@ -0,0 +1,67 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeBounds(t *testing.T) {
+	type event struct {
+		Start   time.Time `validate:"required;min:2020-01-01T00:00:00Z;max:now"`
+		Expires time.Time `validate:"min:now-1h;max:now+24h"`
+		Epoch   time.Time `validate:"in:1970-01-01T00:00:00Z,2000-01-01T00:00:00+02:00"`
+	}
+	now := time.Now()
+	epoch := time.Date(1999, 12, 31, 22, 0, 0, 0, time.UTC)
+	assert.NoError(t, Validate(event{
+		Start:   time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC),
+		Expires: now.Add(time.Hour),
+		Epoch:   epoch,
+	}))
+
+	bad := event{
+		Start:   time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC),
+		Expires: now.Add(-2 * time.Hour),
+		Epoch:   epoch.Add(time.Second),
+	}
+	err := Validate(bad)
+	assert.Equal(t, ValidationErrors{
+		{Err: errors.New(`.Start: validation failed for "min" tag`),
+			StructField: "Start", Path: ".Start", Rule: "min", Param: "2020-01-01T00:00:00Z", Code: "VAL_MIN", Value: bad.Start},
+		{Err: errors.New(`.Expires: validation failed for "min" tag`),
+			StructField: "Expires", Path: ".Expires", Rule: "min", Param: "now-1h", Code: "VAL_MIN", Value: bad.Expires},
+		{Err: errors.New(`.Epoch: validation failed for "in" tag`),
+			StructField: "Epoch", Path: ".Epoch", Rule: "in", Param: "1970-01-01T00:00:00Z,2000-01-01T00:00:00+02:00", Code: "VAL_IN", Value: bad.Epoch},
+	}, err)
+
+	err = Validate(struct {
+		At time.Time `validate:"max:now"`
+	}{At: now.Add(time.Minute)})
+	assert.EqualError(t, err, `.At: validation failed for "max" tag`)
+
+	for _, tag := range []string{"min:2020-01-01", "min:now*2", "min:now+1y"} {
+		assert.Equal(t, ValidationErrors{{Err: ErrInvalidValidatorSyntax}}, Var(now, tag), tag)
+	}
+	assert.Error(t, Var(time.Time{}, "required"))
+}
+
+func TestDurations(t *testing.T) {
+	type config struct {
+		Timeout time.Duration `validate:"required;min:1s;max:5m"`
+		Retry   time.Duration `validate:"in:0,100ms,1.5s"`
+	}
+	assert.NoError(t, Validate(config{Timeout: 30 * time.Second, Retry: 1500 * time.Millisecond}))
+
+	err := Validate(config{Timeout: 10 * time.Minute, Retry: time.Second})
+	assert.EqualError(t, err, `.Timeout: validation failed for "max" tag`+
+		`; .Retry: validation failed for "in" tag`)
+
+	err = Validate(config{Timeout: time.Millisecond})
+	assert.EqualError(t, err, `.Timeout: validation failed for "min" tag`)
+
+	assert.Error(t, Var(time.Duration(0), "required"))
+	assert.Equal(t, ValidationErrors{{Err: ErrInvalidValidatorSyntax}}, Var(time.Second, "min:5"))
+}
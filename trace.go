@@ -0,0 +1,98 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TraceStep is a step of a traced validation: visiting a value when Rule
+// is empty, or evaluating a rule of a value.
+type TraceStep struct {
+	Path string
+	// Type is the type of the visited value, empty for absent values.
+	Type  string
+	Rule  string
+	Param string
+	// Outcome is the outcome of the rule, NotEvaluated when it was
+	// skipped or for visits.
+	Outcome Outcome
+	// Err is the error which stopped the validation at the rule.
+	Err      error
+	Duration time.Duration
+}
+
+// TraceReport is the step by step record of a validation.
+type TraceReport struct {
+	Steps    []TraceStep
+	Duration time.Duration
+}
+
+// String formats the report with a line per step, rules being indented
+// below the value they check.
+func (r TraceReport) String() string {
+	var sb strings.Builder
+	for _, step := range r.Steps {
+		path := step.Path
+		if path == "" {
+			path = "."
+		}
+		if step.Rule == "" {
+			fmt.Fprintf(&sb, "%s %s\n", path, step.Type)
+			continue
+		}
+		rule := step.Rule
+		if step.Param != "" {
+			rule += ":" + step.Param
+		}
+		fmt.Fprintf(&sb, "  %s: %s %v", rule, step.Outcome, step.Duration)
+		if step.Err != nil {
+			fmt.Fprintf(&sb, ": %v", step.Err)
+		}
+		sb.WriteByte('\n')
+	}
+	fmt.Fprintf(&sb, "total %v\n", r.Duration)
+	return sb.String()
+}
+
+// Trace validates v with the package default Validator, recording the
+// steps of the validation.
+func Trace(v any, opts ...Option) (TraceReport, error) {
+	return defaultValidator.Trace(v, opts...)
+}
+
+// Trace validates s like Validate, and returns along with the error a
+// report of the values visited and the rules evaluated, with their
+// parameters, outcomes and timings, to find out why a value failed or
+// passed. Tracing slows validation down.
+func (v *Validator) Trace(s any, opts ...Option) (TraceReport, error) {
+	var report TraceReport
+	opts = append(opts[:len(opts):len(opts)], func(o *options) {
+		o.trace = &report
+	})
+	start := time.Now()
+	err := v.check(s, opts, false).Err()
+	report.Duration = time.Since(start)
+	return report, err
+}
+
+// traceVisit records visiting the value at path.
+func (c *validation) traceVisit(path, typ string) {
+	if c.opts.trace != nil {
+		c.opts.trace.Steps = append(c.opts.trace.Steps, TraceStep{Path: path, Type: typ})
+	}
+}
+
+// traceRule records evaluating rule tr at path from start.
+func (c *validation) traceRule(path string, tr tagRule, o Outcome, err error, start time.Time) {
+	if c.opts.trace != nil {
+		c.opts.trace.Steps = append(c.opts.trace.Steps, TraceStep{
+			Path:     path,
+			Rule:     tr.key,
+			Param:    tr.param.val,
+			Outcome:  o,
+			Err:      err,
+			Duration: time.Since(start),
+		})
+	}
+}
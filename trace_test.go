@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrace(t *testing.T) {
+	type Item struct {
+		Name string `validate:"min:3"`
+	}
+	type Order struct {
+		Items []Item
+		Note  *string `validate:"max:10"`
+		Admin string  `validate:"groups:admin;len:5"`
+	}
+	report, err := Trace(&Order{Items: []Item{{Name: "ab"}}})
+	assert.EqualError(t, err, `.Items[0].Name: validation failed for "min" tag`)
+
+	type step struct {
+		path, typ, rule, param string
+		outcome                Outcome
+	}
+	var steps []step
+	for _, s := range report.Steps {
+		steps = append(steps, step{s.Path, s.Type, s.Rule, s.Param, s.Outcome})
+		assert.GreaterOrEqual(t, int64(s.Duration), int64(0))
+	}
+	assert.Equal(t, []step{
+		{"", "validate.Order", "", "", NotEvaluated},
+		{".Items", "[]validate.Item", "", "", NotEvaluated},
+		{".Items[0]", "validate.Item", "", "", NotEvaluated},
+		{".Items[0].Name", "string", "", "", NotEvaluated},
+		{".Items[0].Name", "", "min", "3", Failed},
+		{".Note", "", "", "", NotEvaluated},
+		{".Note", "", "max", "10", NotEvaluated},
+		{".Admin", "string", "", "", NotEvaluated},
+		{".Admin", "", "groups", "admin", NotEvaluated},
+	}, steps)
+	assert.Positive(t, report.Duration)
+	out := report.String()
+	assert.Contains(t, out, ".Items[0].Name string\n  min:3: failed ")
+	assert.True(t, strings.HasPrefix(out, ". validate.Order\n"))
+
+	report, err = Trace(struct {
+		N int `validate:"quota:n"`
+	}{})
+	assert.Error(t, err)
+	last := report.Steps[len(report.Steps)-1]
+	assert.Equal(t, "quota", last.Rule)
+	assert.ErrorContains(t, last.Err, "no QuotaProvider")
+	assert.Contains(t, report.String(), "quota:n: failed")
+
+	_, err = Trace(1)
+	assert.True(t, errors.Is(err, ErrNotStruct))
+}
@@ -0,0 +1,87 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// UnitRange is the range of the values allowed for a unit of measure.
+type UnitRange struct {
+	Min, Max float64
+}
+
+// unitRanges are the units known to the unit rule by default.
+var unitRanges = map[string]UnitRange{
+	"%":   {0, 100},
+	"C":   {-273.15, math.Inf(1)},
+	"F":   {-459.67, math.Inf(1)},
+	"K":   {0, math.Inf(1)},
+	"Pa":  {0, math.Inf(1)},
+	"hPa": {0, math.Inf(1)},
+	"ppm": {0, 1e6},
+	"lux": {0, math.Inf(1)},
+	"dB":  {math.Inf(-1), math.Inf(1)},
+}
+
+// WithUnitRange registers the range of the values allowed for unit by the
+// unit rule, replacing the built-in or previously registered range.
+func WithUnitRange(unit string, min, max float64) Option {
+	return func(o *options) {
+		res := make(map[string]UnitRange, len(o.unitRanges)+1)
+		for u, r := range o.unitRanges {
+			res[u] = r
+		}
+		res[unit] = UnitRange{min, max}
+		o.unitRanges = res
+	}
+}
+
+// unitRule checks a value/unit pair: "unit:Unit" requires a number to lie
+// in the range of the unit of measure held by the string field Unit, such
+// as "C" or "%". Values with an unknown unit fail, and nil values are left
+// to required.
+var unitRule = rule{
+	assertField: func(fl fieldLevel, p param) (bool, error) {
+		u, err := p.compiled.(*fieldRef).value(fl)
+		if err != nil {
+			return false, err
+		}
+		v := indirect(fl.field)
+		if !v.IsValid() {
+			return true, nil
+		}
+		var f float64
+		switch k := v.Kind(); {
+		case isIntKind(k):
+			f = float64(v.Int())
+		case isUintKind(k):
+			f = float64(v.Uint())
+		case isFloatKind(k):
+			f = v.Float()
+		default:
+			return false, fmt.Errorf("unit: unsupported type %s", v.Type())
+		}
+		if u = indirect(u); u.Kind() != reflect.String {
+			return false, nil
+		}
+		r, ok := p.opts.unitRanges[u.String()]
+		if !ok {
+			r, ok = unitRanges[u.String()]
+		}
+		return ok && r.Min <= f && f <= r.Max, nil
+	},
+	compile: func(p param, owner reflect.Type) (any, error) {
+		if owner == nil {
+			return nil, fmt.Errorf("%w: unit is only supported on struct fields", ErrInvalidValidatorSyntax)
+		}
+		ref, err := newFieldRef(owner, p.val)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unit: %v", ErrInvalidValidatorSyntax, err)
+		}
+		if ref.typ != nil && indirectType(ref.typ).Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: unit: field %q is not a string", ErrInvalidValidatorSyntax, p.val)
+		}
+		return ref, nil
+	},
+}
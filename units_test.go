@@ -0,0 +1,35 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitRule(t *testing.T) {
+	type Reading struct {
+		Value        float64 `validate:"unit:Unit"`
+		Unit         string
+		Humidity     *int `validate:"unit:HumidityUnit"`
+		HumidityUnit string
+	}
+	h := 101
+	assert.NoError(t, Validate(Reading{Value: -40, Unit: "C"}))
+	assert.NoError(t, Validate(Reading{Value: 0, Unit: "K"}))
+	assert.EqualError(t, Validate(Reading{Value: -274, Unit: "C", Humidity: &h, HumidityUnit: "%"}),
+		`.Value: validation failed for "unit" tag`+
+			`; .Humidity: validation failed for "unit" tag`)
+	assert.EqualError(t, Validate(Reading{Value: 1, Unit: "furlong"}),
+		`.Value: validation failed for "unit" tag`)
+
+	opt := WithUnitRange("rpm", 0, 20000)
+	assert.NoError(t, Validate(Reading{Value: 3000, Unit: "rpm"}, opt))
+	assert.Error(t, Validate(Reading{Value: 30000, Unit: "rpm"}, opt))
+	assert.NoError(t, Validate(Reading{Value: 150, Unit: "%"}, WithUnitRange("%", 0, 200)))
+
+	type Bad struct {
+		Value int `validate:"unit:Unit"`
+		Unit  int
+	}
+	assert.ErrorContains(t, Validate(Bad{}), `unit: field "Unit" is not a string`)
+}
@@ -0,0 +1,60 @@
+// Package validatetest provides a test double for validate.ValidatorIface.
+package validatetest
+
+import (
+	"context"
+	"sync"
+
+	validate "github.com/UNEXPECTEDsemicolon/go-validate"
+)
+
+// Validator is a validate.ValidatorIface which validates nothing: it
+// returns canned errors and records the values it was given.
+type Validator struct {
+	// Err is returned by every call unless ErrFunc is set.
+	Err error
+	// ErrFunc, when set, returns the error for the validated value.
+	ErrFunc func(v any) error
+
+	mu    sync.Mutex
+	calls []any
+}
+
+var _ validate.ValidatorIface = (*Validator)(nil)
+
+func (v *Validator) Validate(s any, opts ...validate.Option) error {
+	return v.call(s)
+}
+
+func (v *Validator) ValidateCtx(ctx context.Context, s any, opts ...validate.Option) error {
+	return v.call(s)
+}
+
+func (v *Validator) ValidatePartial(s any, selectors ...string) error {
+	return v.call(s)
+}
+
+func (v *Validator) ValidateExcept(s any, selectors ...string) error {
+	return v.call(s)
+}
+
+func (v *Validator) Var(val any, tag string, opts ...validate.Option) error {
+	return v.call(val)
+}
+
+// Calls returns the values passed to v so far, in call order.
+func (v *Validator) Calls() []any {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]any(nil), v.calls...)
+}
+
+func (v *Validator) call(val any) error {
+	v.mu.Lock()
+	v.calls = append(v.calls, val)
+	v.mu.Unlock()
+	if v.ErrFunc != nil {
+		return v.ErrFunc(val)
+	}
+	return v.Err
+}
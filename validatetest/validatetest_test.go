@@ -0,0 +1,27 @@
+package validatetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidator(t *testing.T) {
+	errInvalid := errors.New("invalid")
+	v := &Validator{Err: errInvalid}
+	assert.ErrorIs(t, v.Validate(struct{}{}), errInvalid)
+	assert.ErrorIs(t, v.Var(3, "min:5"), errInvalid)
+	assert.ErrorIs(t, v.ValidateCtx(context.Background(), 4), errInvalid)
+
+	v.ErrFunc = func(val any) error {
+		if val == 1 {
+			return nil
+		}
+		return errInvalid
+	}
+	assert.NoError(t, v.ValidatePartial(1, "A"))
+	assert.ErrorIs(t, v.ValidateExcept(2, "A"), errInvalid)
+	assert.Equal(t, []any{struct{}{}, 3, 4, 1, 2}, v.Calls())
+}
@@ -1,200 +1,889 @@
 package validate
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
-	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 var ErrNotStruct = errors.New("wrong argument given, should be a struct")
 var ErrInvalidValidatorSyntax = errors.New("invalid validator syntax")
 var ErrValidateForUnexportedFields = errors.New("validation for unexported field is not allowed")
+var ErrMaxDepthExceeded = errors.New("maximum validation depth exceeded")
 
-type ValidationError struct {
+// ErrNumericOverflow matches the errors of numeric rules whose integer
+// parameter is out of the range of the values they are compared with, see
+// OverflowPolicy. It also matches ErrInvalidValidatorSyntax.
+var ErrNumericOverflow = fmt.Errorf("%w: numeric overflow", ErrInvalidValidatorSyntax)
+
+// ErrRuleFailed matches, with errors.Is, the failures of any rule, as
+// opposed to errors preventing validation like ErrInvalidValidatorSyntax.
+// See RuleFailed to match the failures of a given rule.
+var ErrRuleFailed = errors.New("validation failed")
+
+// ruleFailure is the error returned by RuleFailed.
+type ruleFailure string
+
+func (r ruleFailure) Error() string {
+	return fmt.Sprintf("validation failed for %q tag", string(r))
+}
+
+// RuleFailed returns an error matching, with errors.Is, the failures of
+// rule, e.g. errors.Is(err, RuleFailed("required")).
+func RuleFailed(rule string) error {
+	return ruleFailure(rule)
+}
+
+// FieldError is the failure of a rule of a field.
+type FieldError struct {
 	Err error
+	// Suggestion is a close valid value, see WithSuggestions.
+	Suggestion string
+	// Description is the desc tag of the failed field, or of the nearest
+	// field holding it, e.g. desc:"Customer legal name".
+	Description string
+	// DocURL links to the documentation of the failure, see WithDocURL.
+	DocURL string
+	// StructField is the Go name of the field holding the failed value,
+	// e.g. Name for .Items[2].Name. It is empty for values given to Var.
+	StructField string
+	// Path locates the failed value, e.g. .Items[2].Name.
+	Path string
+	// Rule is the name of the failed rule and Param its parameter.
+	Rule, Param string
+	// Code identifies the failed rule for programs, e.g. VAL_MIN. Codes
+	// are the rule names in upper case prefixed with VAL_, and do not
+	// change unlike messages.
+	Code string
+	// Value is the failed value, nil when it is absent.
+	Value any
 }
 
-type ValidationErrors []ValidationError
+// ValidationError is the former name of FieldError.
+type ValidationError = FieldError
 
-type validator struct {
-	assertInt func(val int, keyVal string) (bool, error)
-	assertStr func(val string, keyVal string) (bool, error)
+func (e FieldError) Error() string {
+	return e.Err.Error()
 }
 
-var validators = map[string]validator{
-	"len": {
-		assertInt: func(val int, keyVal string) (bool, error) {
-			return true, nil
-		},
-		assertStr: func(val, keyVal string) (bool, error) {
-			trueLen, err := strconv.Atoi(keyVal)
-			if err != nil {
-				return false, ErrInvalidValidatorSyntax
-			}
-			return len(val) == trueLen, nil
-		},
-	},
-	"in": {
-		assertInt: func(val int, keyVal string) (bool, error) {
-			set := strings.Split(keyVal, ",")
-			for _, elem := range set {
-				elemInt, err := strconv.Atoi(elem)
-				if err != nil {
-					return false, ErrInvalidValidatorSyntax
-				}
-				if val == elemInt {
-					return true, nil
-				}
-			}
-			return false, nil
-		},
-		assertStr: func(val, keyVal string) (bool, error) {
-			set := strings.Split(keyVal, ",")
-			for _, elem := range set {
-				if val == elem {
-					return true, nil
-				}
-			}
-			return false, nil
-		},
-	},
-	"min": {
-		assertInt: func(val int, keyVal string) (bool, error) {
-			min, err := strconv.Atoi(keyVal)
-			if err != nil {
-				return false, ErrInvalidValidatorSyntax
-			}
-			return val >= min, nil
-		},
-		assertStr: func(val, keyVal string) (bool, error) {
-			min, err := strconv.Atoi(keyVal)
-			if err != nil {
-				return false, ErrInvalidValidatorSyntax
-			}
-			return len(val) >= min, nil
-		},
-	},
-	"max": {
-		assertInt: func(val int, keyVal string) (bool, error) {
-			max, err := strconv.Atoi(keyVal)
-			if err != nil {
-				return false, ErrInvalidValidatorSyntax
-			}
-			return val <= max, nil
-		},
-		assertStr: func(val, keyVal string) (bool, error) {
-			max, err := strconv.Atoi(keyVal)
-			if err != nil {
-				return false, ErrInvalidValidatorSyntax
-			}
-			return len(val) <= max, nil
-		},
-	},
+// Unwrap returns the underlying error, e.g. ErrInvalidValidatorSyntax for
+// an invalid tag.
+func (e FieldError) Unwrap() error {
+	return e.Err
 }
 
-var TagRegexp = regexp.MustCompile(`^(?:([a-z]+):([[:alnum:]:,-]*))(?:;([a-z]+):([[:alnum:]:,-]*))*?$`)
+// Is reports whether e is the failure of a rule matching target, either
+// ErrRuleFailed or the result of RuleFailed.
+func (e FieldError) Is(target error) bool {
+	if rule, ok := target.(ruleFailure); ok {
+		return e.Rule == string(rule)
+	}
+	return target == ErrRuleFailed && e.Rule != ""
+}
 
-func (v ValidationErrors) Error() (res string) {
-	for _, err := range v {
-		res += err.Err.Error()
+// ValidationErrors lists the failures of a validation in a stable order:
+// fields in declaration order, slice elements by index and map entries by
+// the canonical form of their keys, and the rules of a field in tag order.
+// Sort orders them by path and rule instead.
+type ValidationErrors []FieldError
+
+// Error joins the messages of the errors of v with "; ".
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, err := range v {
+		msgs[i] = err.Err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Format implements fmt.Formatter: %+v writes the errors of v one per line
+// with their path, rule and value, e.g.
+// .Name [min:3] value="al": .Name: validation failed for "min" tag
+// while other verbs write the message of Error.
+func (v ValidationErrors) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		for i, fe := range v {
+			if i > 0 {
+				io.WriteString(f, "\n")
+			}
+			io.WriteString(f, fe.details())
+		}
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", v.Error())
+	default:
+		io.WriteString(f, v.Error())
 	}
-	return
 }
 
-func (v *validator) Validate(tagVal string, vFieldVal any) (res bool, err error) {
-	if len(tagVal) == 0 {
-		return false, nil
+// details describes e on a line, with its path, rule and value.
+func (e FieldError) details() string {
+	if e.Rule == "" {
+		return e.Err.Error()
 	}
-	totalOk := false
-	if valInt, ok := vFieldVal.(int); ok {
-		totalOk = true
-		res, err = v.assertInt(valInt, tagVal)
-		if err != nil || !res {
-			return
+	var sb strings.Builder
+	if e.Path != "" {
+		sb.WriteString(e.Path)
+		sb.WriteByte(' ')
+	}
+	sb.WriteString("[" + e.Rule)
+	if e.Param != "" {
+		sb.WriteString(":" + e.Param)
+	}
+	sb.WriteByte(']')
+	switch val := e.Value.(type) {
+	case nil:
+	case string:
+		sb.WriteString(" value=" + strconv.Quote(val))
+	default:
+		fmt.Fprintf(&sb, " value=%v", val)
+	}
+	sb.WriteString(": " + e.Err.Error())
+	return sb.String()
+}
+
+// Unwrap returns the errors of v, so that errors.Is and errors.As look
+// into each of them, e.g. to extract the first FieldError.
+func (v ValidationErrors) Unwrap() []error {
+	res := make([]error, len(v))
+	for i, err := range v {
+		res[i] = err
+	}
+	return res
+}
+
+// Filter returns the errors of v for which keep returns true, or nil.
+func (v ValidationErrors) Filter(keep func(FieldError) bool) ValidationErrors {
+	var res ValidationErrors
+	for _, fe := range v {
+		if keep(fe) {
+			res = append(res, fe)
 		}
 	}
-	if valStr, ok := vFieldVal.(string); ok {
-		totalOk = true
-		res, err = v.assertStr(valStr, tagVal)
-		if err != nil || !res {
-			return
+	return res
+}
+
+// ByField returns the errors of v about field, either a Go field name
+// like Email, matching every field of that name, or a path like
+// .Address.Email, with or without its leading dot.
+func (v ValidationErrors) ByField(field string) ValidationErrors {
+	return v.Filter(func(fe FieldError) bool {
+		return fe.StructField == field || fe.Path != "" && (fe.Path == field || fe.Path == "."+field)
+	})
+}
+
+// ByRule returns the failures of rule in v.
+func (v ValidationErrors) ByRule(rule string) ValidationErrors {
+	return v.Filter(func(fe FieldError) bool {
+		return fe.Rule == rule
+	})
+}
+
+// Sort sorts v in place by path and then by rule. Paths are compared
+// segment by segment, indexes numerically, so that .Items[2] comes before
+// .Items[10] and a field before the fields nested in it.
+func (v ValidationErrors) Sort() {
+	keys := make([][]string, len(v))
+	for i := range v {
+		keys[i] = pathKeys(v[i].Path)
+	}
+	sort.Stable(errorsByPath{v, keys})
+}
+
+// errorsByPath sorts errors along with the segments of their paths.
+type errorsByPath struct {
+	errs ValidationErrors
+	keys [][]string
+}
+
+func (s errorsByPath) Len() int { return len(s.errs) }
+
+func (s errorsByPath) Swap(i, j int) {
+	s.errs[i], s.errs[j] = s.errs[j], s.errs[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+func (s errorsByPath) Less(i, j int) bool {
+	if c := comparePathKeys(s.keys[i], s.keys[j]); c != 0 {
+		return c < 0
+	}
+	return s.errs[i].Rule < s.errs[j].Rule
+}
+
+// pathKeys splits path, an error path or a JSON Pointer, into the names
+// and keys of its segments.
+func pathKeys(path string) []string {
+	if strings.HasPrefix(path, "/") {
+		return strings.Split(path[1:], "/")
+	}
+	segs, err := ParseFieldPath(path)
+	if err != nil {
+		return []string{path}
+	}
+	keys := make([]string, len(segs))
+	for i, seg := range segs {
+		keys[i] = seg.Field + seg.Key
+	}
+	return keys
+}
+
+// comparePathKeys compares the segments of two paths, integers
+// numerically.
+func comparePathKeys(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		m, errM := strconv.ParseInt(a[i], 10, 64)
+		n, errN := strconv.ParseInt(b[i], 10, 64)
+		if errM == nil && errN == nil {
+			if m < n {
+				return -1
+			}
+			return 1
 		}
+		return strings.Compare(a[i], b[i])
 	}
-	if !totalOk {
-		err = fmt.Errorf("unsupported type %T", vFieldVal)
-		return
+	return len(a) - len(b)
+}
+
+// First returns the first error of v, and false if v is empty.
+func (v ValidationErrors) First() (FieldError, bool) {
+	if len(v) == 0 {
+		return FieldError{}, false
 	}
-	return true, nil
+	return v[0], true
+}
+
+// Validator validates structs using a set of default options.
+// A single Validator may be shared between goroutines.
+type Validator struct {
+	opts  options
+	cache *planCache
+}
+
+// New returns a Validator configured with the given default options.
+func New(opts ...Option) *Validator {
+	o := defaultOptions().with(opts)
+	return &Validator{
+		opts:  o,
+		cache: newPlanCache(o.cacheSize),
+	}
+}
+
+// Child returns a Validator whose defaults are v's defaults with opts
+// layered on top. The child shares v's plan cache, so types already
+// compiled by v are not parsed again.
+func (v *Validator) Child(opts ...Option) *Validator {
+	return &Validator{
+		opts:  v.opts.with(opts),
+		cache: v.cache,
+	}
+}
+
+// ClearCache drops the plans cached by v and its children.
+func (v *Validator) ClearCache() {
+	v.cache.clear()
+}
+
+var defaultValidator = New()
+
+// Check validates v with the package default Validator.
+func Check(v any, opts ...Option) Result {
+	return defaultValidator.Check(v, opts...)
+}
+
+// Var validates a single value against tag with the package default Validator.
+func Var(val any, tag string, opts ...Option) error {
+	return defaultValidator.Var(val, tag, opts...)
+}
+
+// ClearCache drops the plans cached by the package default Validator.
+func ClearCache() {
+	defaultValidator.ClearCache()
 }
 
-func Validate(v any) error {
-	vVal := reflect.ValueOf(v)
+// Validate validates v with the package default Validator.
+func Validate(v any, opts ...Option) error {
+	return defaultValidator.Validate(v, opts...)
+}
+
+// Validate validates s, a struct or a pointer to a struct. Options given
+// here are layered over the Validator's defaults and affect only this call.
+// Valid structs whose fields have basic kinds like int and string, or
+// hold such structs, are validated without allocating when no options
+// are given.
+func (v *Validator) Validate(s any, opts ...Option) error {
+	return v.check(s, opts, false).Err()
+}
+
+// Check validates s like Validate, returning the outcome as a Result.
+func (v *Validator) Check(s any, opts ...Option) Result {
+	return v.check(s, opts, true)
+}
+
+// check validates s, recording per field outcomes if asked to.
+func (v *Validator) check(s any, opts []Option, withOutcomes bool) Result {
+	return v.checkCtx(context.Background(), s, opts, withOutcomes)
+}
+
+// checkCtx is check passing ctx to the providers rules depend on.
+func (v *Validator) checkCtx(ctx context.Context, s any, opts []Option, withOutcomes bool) Result {
+	vVal := reflect.ValueOf(s)
+	if vVal.Kind() == reflect.Pointer && vVal.Type().Elem().Kind() == reflect.Struct && !vVal.IsNil() {
+		vVal = vVal.Elem()
+	}
+	c := validations.Get().(*validation)
+	*c = validation{opts: v.opts.with(opts), cache: v.cache, root: vVal, ctx: ctx}
+	defer func() {
+		*c = validation{}
+		validations.Put(c)
+	}()
+	if withOutcomes || c.opts.recorder != nil {
+		c.outcomes = make(map[string]Outcome)
+	}
 	if vVal.Kind() != reflect.Struct {
-		return ErrNotStruct
+		return Result{err: ErrNotStruct}
 	}
-	valErrs, err := validateImpl(vVal, make([]string, 0), "")
-	if err != nil {
-		return ValidationErrors{ValidationError{err}}
+	var err error
+	if c.only, err = parseSelectors(c.opts.only); err != nil {
+		return Result{err: err}
 	}
-	if len(valErrs) == 0 {
-		return nil
+	if c.except, err = parseSelectors(c.opts.except); err != nil {
+		return Result{err: err}
+	}
+	if c.fast() {
+		// Valid flat structs need none of the bookkeeping of validateImpl.
+		if plan := c.cache.plan(vVal.Type(), &c.opts); plan.flat && c.fastValid(vVal, plan, 1) {
+			return Result{}
+		}
+	}
+	valErrs, err := c.validateImpl(vVal, nil, "", nil)
+	if c.opts.recorder != nil && err == nil && len(valErrs) > 0 {
+		c.opts.recorder.record(vVal, c.outcomes)
+	}
+	if !withOutcomes {
+		c.outcomes = nil
+	}
+	return Result{errs: c.capped(valErrs), err: err, outcomes: c.outcomes, warnings: c.warnings}
+}
+
+// Var validates a single value against tag, e.g. Var(age, "min:18").
+// Rules needing a struct, like expr, are not supported. Tags are parsed
+// once and cached like struct plans, see WithCacheSize, so parameters
+// varying per call are better given as data references, see WithData.
+func (v *Validator) Var(val any, tag string, opts ...Option) error {
+	c := &validation{opts: v.opts.with(opts), cache: v.cache}
+	tags := []fieldTag{v.cache.varTag(tag, &c.opts)}
+	valErrs, err := c.validateImpl(reflect.ValueOf(val), tags, "", nil)
+	return Result{errs: c.capped(valErrs), err: err}.Err()
+}
+
+// validations recycles the state of Validate calls, so that validating
+// valid values does not allocate.
+var validations = sync.Pool{New: func() any { return new(validation) }}
+
+// validation holds the state of a single Validate call.
+type validation struct {
+	opts  options
+	cache *planCache
+	root  reflect.Value
+	ctx   context.Context
+	// only and except are the parsed WithPartial and WithExcept selectors.
+	only, except []pathSelector
+	// outcomes is nil unless the caller asked for a Result.
+	outcomes map[string]Outcome
+	// depth is the nesting level of the value being validated, and
+	// visiting holds the pointers followed to reach it, to stop at cycles.
+	depth    int
+	visiting map[visit]bool
+	// field is the Go name of the innermost field being validated, and
+	// doc the doc tag of the innermost field having one.
+	field, doc string
+	// errCount is the number of rule failures found so far.
+	errCount int
+	// reported holds the failures found so far, to drop duplicates.
+	reported map[errorKey]bool
+	// warnings holds the failures of warning rules, see Result.Warnings.
+	warnings ValidationErrors
+}
+
+// errorKey identifies a failure when dropping duplicates.
+type errorKey struct {
+	path, rule, param string
+}
+
+// duplicate reports whether a failure with the path, rule and parameter
+// of fe was already found, unless WithDuplicateErrors is set.
+func (c *validation) duplicate(fe FieldError) bool {
+	if c.opts.keepDuplicates {
+		return false
+	}
+	key := errorKey{fe.Path, fe.Rule, fe.Param}
+	if c.reported[key] {
+		return true
+	}
+	if c.reported == nil {
+		c.reported = make(map[errorKey]bool)
+	}
+	c.reported[key] = true
+	return false
+}
+
+// full reports whether enough failures were found to stop validating,
+// see WithFailFast and WithMaxErrors.
+func (c *validation) full() bool {
+	return c.opts.failFast && c.errCount > 0 || c.opts.maxErrors > 0 && c.errCount >= c.opts.maxErrors
+}
+
+// capped returns valErrs without the errors beyond WithMaxErrors.
+func (c *validation) capped(valErrs ValidationErrors) ValidationErrors {
+	if c.opts.maxErrors > 0 && len(valErrs) > c.opts.maxErrors {
+		return valErrs[:c.opts.maxErrors]
 	}
 	return valErrs
 }
 
-func validateImpl(vVal reflect.Value, vTags []string, callstack string) (valErrs ValidationErrors, err error) {
-	if vVal.Type().Kind() == reflect.Array || vVal.Type().Kind() == reflect.Slice {
+// visit identifies a pointer being followed.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// record notes the outcome of a rule or of skipping rules at path.
+// Failures take precedence over passes, which take precedence over skips.
+func (c *validation) record(path string, o Outcome) {
+	if c.outcomes == nil {
+		return
+	}
+	if cur, ok := c.outcomes[path]; !ok || o > cur {
+		c.outcomes[path] = o
+	}
+}
+
+// validateImpl validates vVal against vTags, the tags accumulated from the
+// fields holding it. plan, when set, replaces the cached plan of the struct
+// type of vVal.
+func (c *validation) validateImpl(vVal reflect.Value, vTags []fieldTag, callstack string, plan *structPlan) (valErrs ValidationErrors, err error) {
+	o := &c.opts
+	sel := c.selection(callstack)
+	if sel == selectNone {
+		return nil, nil
+	}
+	if c.depth++; o.maxDepth > 0 && c.depth > o.maxDepth {
+		return nil, ErrMaxDepthExceeded
+	}
+	defer func() { c.depth-- }()
+	if vVal.Kind() == reflect.Pointer && !vVal.IsNil() {
+		// A value already being validated up the stack is part of a cycle.
+		key := visit{vVal.Pointer(), vVal.Type()}
+		if c.visiting[key] {
+			return nil, nil
+		}
+		if c.visiting == nil {
+			c.visiting = make(map[visit]bool)
+		}
+		c.visiting[key] = true
+		defer delete(c.visiting, key)
+	}
+	vVal, wrapped := derefPointers(vVal)
+	inner, ok, err := c.unwrap(vVal)
+	if err != nil {
+		if callstack != "" {
+			err = fmt.Errorf("%s: %v", callstack, err)
+		}
+		return nil, err
+	}
+	if ok {
+		// An empty optional is left as the zero Value, a leaf.
+		vVal, wrapped = inner, true
+	}
+	if o.trace != nil {
+		typ := ""
+		if vVal.IsValid() {
+			typ = vVal.Type().String()
+		}
+		c.traceVisit(callstack, typ)
+	}
+	scalar := vVal.IsValid() && isScalar(vVal.Type(), o)
+	if !scalar && (vVal.Kind() == reflect.Array || vVal.Kind() == reflect.Slice) {
+		if c.opts.parallelism > 1 && vVal.Len() > 1 && c.opts.trace == nil {
+			return c.validateElemsParallel(vVal, vTags, callstack)
+		}
 		for i := 0; i < vVal.Len(); i++ {
-			newValErrs, err := validateImpl(vVal.Index(i), vTags, callstack+fmt.Sprintf("[%d]", i))
+			newValErrs, err := c.validateImpl(vVal.Index(i), vTags, callstack+fmt.Sprintf("[%d]", i), nil)
 			if err != nil {
 				return nil, err
 			}
 			valErrs = append(valErrs, newValErrs...)
+			if c.full() {
+				return valErrs, nil
+			}
 		}
-	} else if vVal.Type().Kind() == reflect.Struct {
-		for i := 0; i < vVal.Type().NumField(); i++ {
-			field := vVal.Type().Field(i)
-			tag, tagOk := field.Tag.Lookup("validate")
-			if tagOk && !field.IsExported() {
-				return nil, ErrValidateForUnexportedFields
+	} else if !scalar && vVal.Kind() == reflect.Map {
+		// Tags with keys: or values: sections apply their other rules to the
+		// map itself; tags without apply to the values.
+		var keyTags, valueTags []fieldTag
+		for i := range vTags {
+			tag := &vTags[i]
+			if !tag.sectioned() {
+				valueTags = append(valueTags, *tag)
+				continue
+			}
+			if tag.keys != nil {
+				keyTags = append(keyTags, fieldTag{rules: tag.keys, msg: tag.msg})
 			}
-			newValErrs, err := validateImpl(vVal.Field(i), append(vTags, tag), callstack+"."+field.Name)
+			if tag.values != nil {
+				valueTags = append(valueTags, fieldTag{rules: tag.values, msg: tag.msg})
+			}
+			if tag.rules == nil || sel != selectAll {
+				continue
+			}
+			newValErrs, err := c.checkTag(tag, vVal, reflect.Value{}, callstack, wrapped)
 			if err != nil {
 				return nil, err
 			}
 			valErrs = append(valErrs, newValErrs...)
+			if c.full() {
+				return valErrs, nil
+			}
 		}
-	} else {
-		for _, tag := range vTags {
-			if len(tag) == 0 {
-				continue
+		for _, key := range sortedMapKeys(vVal) {
+			newValErrs, err := c.validateImpl(key.val, keyTags, mapKeyPath(callstack, key.formatted), nil)
+			if err != nil {
+				return nil, err
 			}
-			matches := TagRegexp.FindStringSubmatch(tag)
-			if matches == nil || len(matches) < 3 {
-				return nil, ErrInvalidValidatorSyntax
+			valErrs = append(valErrs, newValErrs...)
+			newValErrs, err = c.validateImpl(vVal.MapIndex(key.val), valueTags, mapValuePath(callstack, key.formatted), nil)
+			if err != nil {
+				return nil, err
 			}
-			for i := 1; i < len(matches); i += 2 {
-				if len(matches[i]) == 0 {
-					break
-				}
-				tagKey, tagVal := matches[i], matches[i+1]
-				validator, exists := validators[tagKey]
-				if !exists {
-					return nil, fmt.Errorf("%v: unsupported tag %q", ErrInvalidValidatorSyntax, tagKey)
+			valErrs = append(valErrs, newValErrs...)
+			if c.full() {
+				return valErrs, nil
+			}
+		}
+	} else if !scalar && vVal.Kind() == reflect.Struct {
+		if plan == nil || plan.typ != vVal.Type() {
+			plan = c.cache.plan(vVal.Type(), o)
+		}
+		if plan.flat && len(vTags) == 0 && c.fast() && c.fastValid(vVal, plan, c.depth) {
+			return nil, nil
+		}
+		defer func(field, doc string) { c.field, c.doc = field, doc }(c.field, c.doc)
+		outerDoc := c.doc
+		for i, field := range plan.fields {
+			c.field, c.doc = field.name, outerDoc
+			if field.doc != "" {
+				c.doc = field.doc
+			}
+			path := fieldPath(callstack, plan.pathName(i, o.fieldNames))
+			if field.unexported {
+				if !o.skipUnexported {
+					return nil, ErrValidateForUnexportedFields
 				}
-				var res bool
-				res, err = validator.Validate(tagVal, vVal.Interface())
+				c.record(path, NotEvaluated)
+				continue
+			}
+			if c.selection(path) != selectAll && !field.tag.empty() {
+				c.record(path, NotEvaluated)
+			} else {
+				newValErrs, err := c.checkTag(&field.tag, vVal.Field(i), vVal, path, false)
 				if err != nil {
-					return
+					return nil, err
 				}
-				if !res {
-					valErrs = append(valErrs, ValidationError{fmt.Errorf("%s: validation failed for %q tag", callstack, tagKey)})
+				if field.whole && !field.tag.empty() {
+					embedded, wrapped := derefPointers(vVal.Field(i))
+					wholeErrs, err := c.checkTag(&field.tag, embedded, reflect.Value{}, path, wrapped)
+					if err != nil {
+						return nil, err
+					}
+					newValErrs = append(newValErrs, wholeErrs...)
 				}
+				valErrs = append(valErrs, field.describe(newValErrs)...)
+				if c.full() {
+					return valErrs, nil
+				}
+			}
+			fieldTags, childPath := vTags, path
+			if field.whole && o.promotedPaths {
+				childPath = callstack
+			}
+			if !field.tag.empty() && !field.whole {
+				// vTags may be shared, e.g. by the elements of a slice
+				// validated concurrently, so it is not appended to.
+				fieldTags = append(vTags[:len(vTags):len(vTags)], field.tag)
+			}
+			newValErrs, err := c.validateImpl(vVal.Field(i), fieldTags, childPath, field.plan)
+			if err != nil {
+				return nil, err
+			}
+			valErrs = append(valErrs, field.describe(newValErrs)...)
+			if c.full() {
+				return valErrs, nil
+			}
+		}
+	} else if sel == selectAll {
+		for i := range vTags {
+			newValErrs, err := c.checkTag(&vTags[i], vVal, reflect.Value{}, callstack, wrapped)
+			if err != nil {
+				return nil, err
+			}
+			valErrs = append(valErrs, newValErrs...)
+			if c.full() {
+				return valErrs, nil
 			}
 		}
 	}
 	return
 }
+
+// derefPointers dereferences the pointers and interfaces holding v.
+// A nil pointer or interface is absent, like an empty optional: the zero
+// Value is returned. wrapped is set when v was held by a pointer, whose
+// rules then check presence like for optionals.
+func derefPointers(v reflect.Value) (_ reflect.Value, wrapped bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, true
+		}
+		if v.Kind() == reflect.Pointer {
+			wrapped = true
+		}
+		v = v.Elem()
+	}
+	return v, wrapped
+}
+
+// checkTag evaluates the rules of tag against vVal. When parent is valid,
+// vVal is a field of parent and only field rules are evaluated; otherwise
+// vVal is a leaf value and only value rules are. A wrapped leaf value was
+// held by a pointer or an optional, which was absent when vVal is the zero
+// Value.
+func (c *validation) checkTag(tag *fieldTag, vVal, parent reflect.Value, callstack string, wrapped bool) (valErrs ValidationErrors, err error) {
+	isField := parent.IsValid()
+	if tag.err != nil {
+		if isField {
+			return nil, nil
+		}
+		return nil, tag.err
+	}
+	if !c.opts.inGroups(tag.rules) {
+		c.record(callstack, NotEvaluated)
+		if c.opts.trace != nil && !isField {
+			tr, _ := findRule(tag.rules, groupsTag, "")
+			c.traceRule(callstack, tr, NotEvaluated, nil, time.Now())
+		}
+		return nil, nil
+	}
+	target := vVal
+	for _, tr := range tag.rules {
+		if tr.key == groupsTag || !c.opts.applies(tag.rules, tr) {
+			continue
+		}
+		if tr.key == omitemptyTag {
+			if !isField && (!vVal.IsValid() || vVal.IsZero()) {
+				return valErrs, nil
+			}
+			continue
+		}
+		rule, exists := rules[tr.key]
+		if !exists {
+			if isField {
+				continue
+			}
+			return nil, fmt.Errorf("%w: unsupported tag %q at offset %d", ErrInvalidValidatorSyntax, tr.key, tr.pos)
+		}
+		if rule.modify != nil {
+			if !isField && vVal.IsValid() {
+				if vVal, err = rule.modify(vVal); err != nil {
+					return nil, err
+				}
+				c.store(target, vVal)
+			}
+			continue
+		}
+		if (rule.assertField != nil || rule.assertElems != nil) != isField {
+			continue
+		}
+		if rule.assertElems != nil {
+			elemErrs, err := c.checkElems(rule, tr, tag.msg, vVal, callstack)
+			if err != nil {
+				return nil, err
+			}
+			valErrs = append(valErrs, elemErrs...)
+			if c.full() {
+				return valErrs, nil
+			}
+			continue
+		}
+		var res bool
+		var start time.Time
+		if c.opts.trace != nil {
+			start = time.Now()
+		}
+		p := tr.param
+		p.opts, p.ctx = &c.opts, c.ctx
+		if p, err = p.resolve(rule, parent); err != nil {
+			return nil, err
+		}
+		switch {
+		case isField:
+			res, err = rule.assertField(fieldLevel{vVal, parent, c.root, c.opts.data}, p)
+		case wrapped && rule.presence:
+			res = vVal.IsValid()
+		case !vVal.IsValid() && (!wrapped || c.opts.nilPolicy == NilSkip):
+			c.record(callstack, NotEvaluated)
+			c.traceRule(callstack, tr, NotEvaluated, nil, start)
+			continue
+		case !vVal.IsValid():
+			res = false
+		default:
+			res, err = rule.Validate(p, vVal)
+		}
+		if err != nil {
+			c.traceRule(callstack, tr, Failed, err, start)
+			return nil, err
+		}
+		if res {
+			c.record(callstack, Passed)
+			c.traceRule(callstack, tr, Passed, nil, start)
+			if rule.normalize != nil && !isField {
+				norm, _ := rule.normalize(vVal.String())
+				vVal = reflect.ValueOf(norm)
+				c.store(target, vVal)
+			}
+		} else {
+			warn := rule.warn || tr.warn
+			outcome := Failed
+			if warn {
+				outcome = Passed
+			}
+			c.record(callstack, outcome)
+			c.traceRule(callstack, tr, outcome, nil, start)
+			valErr := FieldError{
+				StructField: c.field,
+				Path:        c.errorPath(callstack),
+				Rule:        tr.key,
+				Param:       p.val,
+				Code:        ruleCode(tr.key),
+				Value:       interfaceOf(vVal),
+			}
+			if c.opts.suggestions && rule.suggest != nil && !isField {
+				valErr.Suggestion = rule.suggest(vVal, p)
+			}
+			valErr.DocURL = c.opts.docURL(valErr, c.doc)
+			var detail string
+			if rule.explain != nil {
+				detail = rule.explain(fieldLevel{vVal, parent, c.root, c.opts.data}, p)
+			}
+			valErr.Err = failure(valErr, detail)
+			if tag.msg != "" {
+				valErr.Err = customMessage(tag.msg)
+			}
+			if warn {
+				c.warnings = append(c.warnings, valErr)
+				continue
+			}
+			if c.duplicate(valErr) {
+				continue
+			}
+			valErrs = append(valErrs, valErr)
+			if c.errCount++; c.full() {
+				return valErrs, nil
+			}
+		}
+	}
+	return valErrs, nil
+}
+
+// checkElems evaluates elements rule tr against field vVal, returning an
+// error for each failing element, with message msg when set.
+func (c *validation) checkElems(rule rule, tr tagRule, msg string, vVal reflect.Value, callstack string) (valErrs ValidationErrors, err error) {
+	var start time.Time
+	if c.opts.trace != nil {
+		start = time.Now()
+	}
+	p := tr.param
+	p.opts, p.ctx = &c.opts, c.ctx
+	if p, err = p.resolve(rule, reflect.Value{}); err != nil {
+		return nil, err
+	}
+	failed, err := rule.assertElems(vVal, p)
+	if err != nil {
+		c.traceRule(callstack, tr, Failed, err, start)
+		return nil, err
+	}
+	if len(failed) == 0 {
+		c.record(callstack, Passed)
+		c.traceRule(callstack, tr, Passed, nil, start)
+		return nil, nil
+	}
+	outcome := Failed
+	if tr.warn {
+		outcome = Passed
+	}
+	c.traceRule(callstack, tr, outcome, nil, start)
+	for _, i := range failed {
+		path := callstack + fmt.Sprintf("[%d]", i)
+		c.record(path, outcome)
+		valErr := FieldError{
+			StructField: c.field,
+			Path:        c.errorPath(path),
+			Rule:        tr.key,
+			Param:       p.val,
+			Code:        ruleCode(tr.key),
+			Value:       interfaceOf(indirect(vVal).Index(i)),
+		}
+		valErr.Err = failure(valErr, "")
+		valErr.DocURL = c.opts.docURL(valErr, c.doc)
+		if msg != "" {
+			valErr.Err = customMessage(msg)
+		}
+		if tr.warn {
+			c.warnings = append(c.warnings, valErr)
+			continue
+		}
+		if c.duplicate(valErr) {
+			continue
+		}
+		valErrs = append(valErrs, valErr)
+		if c.errCount++; c.full() {
+			break
+		}
+	}
+	return valErrs, nil
+}
+
+// errorPath returns path in the format of error paths.
+func (c *validation) errorPath(path string) string {
+	if !c.opts.jsonPointers {
+		return path
+	}
+	segs, err := ParseFieldPath(path)
+	if err != nil {
+		return path
+	}
+	return FormatJSONPointer(segs)
+}
+
+// interfaceOf returns the value held by v, or nil if it is absent or
+// unexported.
+func interfaceOf(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// ruleCode returns the code of the failures of rule.
+func ruleCode(rule string) string {
+	return "VAL_" + strings.ToUpper(rule)
+}
@@ -2,7 +2,11 @@ package validate
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -106,7 +110,11 @@ func TestValidate(t *testing.T) {
 					MaxInt    int    `validate:"max:20"`
 					MaxIntNeg int    `validate:"max:-2"`
 					MaxStr    string `validate:"max:20"`
+					ReqInt    int    `validate:"required:"`
+					ReqStr    string `validate:"required:"`
 				}{
+					ReqInt:    1,
+					ReqStr:    "abc",
 					Len:       "abcdefghjklmopqrstvu",
 					LenZ:      "",
 					InInt:     25,
@@ -265,3 +273,574 @@ func TestValidate(t *testing.T) {
 	}
 
 }
+
+func TestValidatorOptions(t *testing.T) {
+	type user struct {
+		ID   int    `validate:"groups:update;min:1"`
+		Name string `validate:"min:3"`
+		Role string `validate:"in:admin,user"`
+	}
+	v := New(WithGroup("update"))
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantLen int
+	}{
+		{
+			name:    "defaults",
+			wantLen: 3,
+		},
+		{
+			name:    "fail fast",
+			opts:    []Option{WithFailFast()},
+			wantLen: 1,
+		},
+		{
+			name:    "fail fast with extra group",
+			opts:    []Option{WithFailFast(), WithGroup("create")},
+			wantLen: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(user{}, tt.opts...)
+			assert.Len(t, err.(ValidationErrors), tt.wantLen)
+		})
+	}
+
+	t.Run("per-call options do not leak", func(t *testing.T) {
+		assert.Len(t, v.Validate(user{}, WithFailFast()).(ValidationErrors), 1)
+		assert.Len(t, v.Validate(user{}).(ValidationErrors), 3)
+	})
+
+	t.Run("grouped rules skipped outside group", func(t *testing.T) {
+		assert.Len(t, Validate(user{}).(ValidationErrors), 2)
+		assert.Len(t, Validate(user{}, WithGroup("create")).(ValidationErrors), 2)
+		assert.Len(t, Validate(user{}, WithGroup("create", "update")).(ValidationErrors), 3)
+	})
+}
+
+func TestRoles(t *testing.T) {
+	type request struct {
+		Limit int               `validate:"max:10;role:admin=max:1000;role:auditor=max:100"`
+		Notes string            `validate:"role:admin=required"`
+		Tags  map[string]string `validate:"role:admin=keys:max:3"`
+	}
+	tests := []struct {
+		name  string
+		req   request
+		roles []string
+		paths []string
+	}{
+		{"no role", request{Limit: 10, Tags: map[string]string{"long": ""}}, nil, nil},
+		{"no role over limit", request{Limit: 11}, nil, []string{".Limit"}},
+		{"admin", request{Limit: 1000, Notes: "n"}, []string{"admin"}, nil},
+		{"admin over limit", request{Limit: 1001, Tags: map[string]string{"long": ""}}, []string{"admin"}, []string{".Limit", ".Notes", `.Tags[key="long"]`}},
+		{"other role", request{Limit: 11}, []string{"guest"}, []string{".Limit"}},
+		{"every role applies", request{Limit: 500, Notes: "n"}, []string{"admin", "auditor"}, []string{".Limit"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.req, WithRole(tt.roles...))
+			if tt.paths == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var paths []string
+			for _, fe := range err.(ValidationErrors) {
+				paths = append(paths, fe.Path)
+			}
+			assert.Equal(t, tt.paths, paths)
+		})
+	}
+
+	assert.NoError(t, Var(500, "max:10;role:admin=max:1000", WithRole("admin")))
+
+	v := New()
+	assert.NoError(t, v.RegisterRules(request{}, map[string]string{"Limit": "role:admin=max:2000"}))
+	assert.NoError(t, v.Validate(request{Limit: 2000, Notes: "n"}, WithRole("admin")))
+	assert.Error(t, v.Validate(request{Limit: 11}))
+	assert.ErrorIs(t, Var(5, "role:max:10"), ErrInvalidValidatorSyntax)
+	assert.ErrorIs(t, Var(5, "role:admin="), ErrInvalidValidatorSyntax)
+
+	type v2 struct {
+		Limit int `validate:"max:10;role:admin=max:500"`
+	}
+	changes, err := CompareRules(struct {
+		Limit int `validate:"max:10;role:admin=max:1000"`
+	}{}, v2{})
+	assert.NoError(t, err)
+	assert.Equal(t, []FieldChange{{Path: ".Limit", Compatibility: Stricter, Rules: []RuleChange{
+		{Rule: "role:admin=max", Old: "role:admin=max:1000", New: "role:admin=max:500", Compatibility: Stricter},
+	}}}, changes)
+}
+
+func TestValidatorSeparators(t *testing.T) {
+	type schedule struct {
+		Start string `validate:"in=09:00 12:30|len=5"`
+		Slots []int  `validate:"in=1 2 4"`
+	}
+	v := New(WithSeparators('|', '=', ' '))
+
+	assert.NoError(t, v.Validate(schedule{Start: "12:30", Slots: []int{1, 4}}))
+
+	err := v.Validate(schedule{Start: "13:00", Slots: []int{3}})
+	assert.Len(t, err.(ValidationErrors), 2)
+
+	err = Validate(schedule{Start: "12:30"})
+	assert.ErrorContains(t, err, ErrInvalidValidatorSyntax.Error())
+}
+
+func TestValidatorSkipUnexported(t *testing.T) {
+	type tag struct {
+		Key string `validate:"min:1"`
+		id  int    `validate:"min:1"`
+	}
+	type record struct {
+		Name  string `validate:"min:3"`
+		cache string `validate:"min:100"`
+		Tags  []tag
+	}
+	r := record{Name: "ab", cache: "x", Tags: []tag{{}}}
+
+	assert.EqualError(t, Validate(r), ErrValidateForUnexportedFields.Error())
+
+	res := Check(r, WithSkipUnexported())
+	assert.EqualError(t, res.Err(), `.Name: validation failed for "min" tag`+
+		`; .Tags[0].Key: validation failed for "min" tag`)
+	assert.Equal(t, NotEvaluated, res.Outcome(".cache"))
+	assert.Equal(t, NotEvaluated, res.Outcome(".Tags[0].id"))
+}
+
+func TestValidatorTagKey(t *testing.T) {
+	type login struct {
+		User     string `binding:"min:3" validate:"len:10"`
+		Password string `binding:"min:8"`
+	}
+	v := New(WithTagKey("binding"))
+
+	assert.NoError(t, v.Validate(login{User: "bob", Password: "secret42"}))
+
+	err := v.Validate(login{User: "bo", Password: "secret"})
+	assert.Len(t, err.(ValidationErrors), 2)
+
+	// Plans for both tag keys are cached side by side.
+	err = v.Validate(login{User: "bob"}, WithTagKey("validate"))
+	assert.EqualError(t, err, `.User: validation failed for "len" tag`)
+}
+
+func TestValidatorTagKeys(t *testing.T) {
+	type account struct {
+		Name  string `validate_gen:"min:1;max:64" validate:"max:20"`
+		Email string `validate_gen:"min:3"`
+		Plan  string `validate:"in:free,pro"`
+	}
+	v := New(WithTagKeys("validate_gen", "validate"))
+
+	assert.NoError(t, v.Validate(account{Name: "bob", Email: "b@x", Plan: "pro"}))
+
+	err := v.Validate(account{Name: strings.Repeat("a", 30), Plan: "gold"})
+	assert.EqualError(t, err, `.Name: validation failed for "max" tag`+
+		`; .Email: validation failed for "min" tag`+
+		`; .Plan: validation failed for "in" tag`)
+
+	err = v.Validate(account{Email: "b@x", Plan: "free"})
+	assert.EqualError(t, err, `.Name: validation failed for "min" tag`)
+}
+
+func TestValidatorChild(t *testing.T) {
+	type order struct {
+		Qty  int    `validate:"min:1"`
+		Note string `validate:"max:3"`
+	}
+	parent := New()
+	child := parent.Child(WithFailFast())
+
+	assert.Same(t, parent.cache, child.cache)
+	assert.Len(t, parent.Validate(order{Note: "long"}).(ValidationErrors), 2)
+	assert.Len(t, child.Validate(order{Note: "long"}).(ValidationErrors), 1)
+
+	assert.NotNil(t, parent.cache.get(planKey{typ: reflect.TypeOf(order{}), syntax: defaultOptions().syntax}))
+}
+
+func TestVar(t *testing.T) {
+	assert.NoError(t, Var(20, "min:18;max:130"))
+	assert.EqualError(t, Var("ab", "min:3"), `validation failed for "min" tag`)
+	assert.EqualError(t, Var([]int{1, 5}, "in:1,2"), `[1]: validation failed for "in" tag`)
+	assert.EqualError(t, Var(1, "min:"), `validation failed for "min" tag`)
+	assert.ErrorContains(t, Var(1, "expr:1 > 0"), "expr is only supported on struct fields")
+	assert.ErrorContains(t, Var(1, "min:1;;"), "empty rule at offset 6")
+}
+
+func TestPointerFields(t *testing.T) {
+	type address struct {
+		City string `validate:"min:2"`
+	}
+	type profile struct {
+		Name    *string  `validate:"required;min:3"`
+		Age     *int     `validate:"min:18"`
+		Nick    *string  `validate:"omitempty;len:4"`
+		Address *address `validate:"required"`
+	}
+	name, age, nick, empty := "Bob", 30, "bobs", ""
+	assert.NoError(t, Validate(profile{Name: &name, Age: &age, Nick: &nick, Address: &address{"Oslo"}}))
+	assert.NoError(t, Validate(profile{Name: &name, Nick: &empty, Address: &address{"Oslo"}}))
+
+	err := Validate(profile{Age: &age, Address: &address{"X"}})
+	assert.Equal(t, ValidationErrors{
+		{Err: errors.New(`.Name: validation failed for "required" tag`),
+			StructField: "Name", Path: ".Name", Rule: "required", Code: "VAL_REQUIRED"},
+		{Err: errors.New(`.Address.City: validation failed for "min" tag`),
+			StructField: "City", Path: ".Address.City", Rule: "min", Param: "2", Code: "VAL_MIN", Value: "X"},
+	}, err)
+
+	err = Validate(profile{Name: &name}, WithNilPolicy(NilFail))
+	assert.Equal(t, ValidationErrors{
+		{Err: errors.New(`.Age: validation failed for "min" tag`),
+			StructField: "Age", Path: ".Age", Rule: "min", Param: "18", Code: "VAL_MIN"},
+		{Err: errors.New(`.Address: validation failed for "required" tag`),
+			StructField: "Address", Path: ".Address", Rule: "required", Code: "VAL_REQUIRED"},
+	}, err)
+
+	assert.NoError(t, Var(&age, "min:18"))
+	assert.Error(t, Var((*int)(nil), "required"))
+}
+
+func TestMapSections(t *testing.T) {
+	type resource struct {
+		Labels map[string]string `validate:"min:1;max:3;keys:max:5;values:min:1"`
+		Ports  map[string]int    `validate:"keys:in:http,https;values:max:65535"`
+		Limits map[string]int    `validate:"max:10"`
+	}
+	assert.NoError(t, Validate(resource{
+		Labels: map[string]string{"env": "prod"},
+		Ports:  map[string]int{"http": 80},
+		Limits: map[string]int{"cpu": 10},
+	}))
+
+	err := Validate(resource{
+		Labels: map[string]string{"env": "", "owner": "ops", "region": "eu"},
+		Ports:  map[string]int{"ftp": 21, "https": 70000},
+		Limits: map[string]int{"cpu": 20},
+	})
+	assert.EqualError(t, err, `.Labels["env"]: validation failed for "min" tag`+
+		`; .Labels[key="region"]: validation failed for "max" tag`+
+		`; .Ports[key="ftp"]: validation failed for "in" tag`+
+		`; .Ports["https"]: validation failed for "max" tag`+
+		`; .Limits["cpu"]: validation failed for "max" tag`)
+
+	err = Validate(resource{})
+	assert.EqualError(t, err, `.Labels: validation failed for "min" tag`)
+
+	err = Validate(struct {
+		M map[string]int `validate:"keys:ma-x:1"`
+	}{M: map[string]int{"a": 1}})
+	assert.ErrorContains(t, err, `":" expected after rule name "ma" at offset 7`)
+}
+
+func TestInterfaceFields(t *testing.T) {
+	type address struct {
+		City string `validate:"min:2"`
+	}
+	type payload struct {
+		Value   any          `validate:"min:3"`
+		Meta    any          `validate:"required"`
+		Target  any          // validated by the rules of its dynamic type
+		Items   []any        `validate:"max:10"`
+		Printer fmt.Stringer `validate:"required"`
+	}
+	city := "Oslo"
+	assert.NoError(t, Validate(payload{
+		Value:   "abc",
+		Meta:    map[string]int{"a": 1},
+		Target:  &address{city},
+		Items:   []any{1, "short", 9.5},
+		Printer: time.Second,
+	}))
+
+	err := Validate(payload{
+		Value:  2,
+		Meta:   "",
+		Target: address{"X"},
+		Items:  []any{11, nil, &city},
+	})
+	assert.EqualError(t, err, `.Value: validation failed for "min" tag`+
+		`; .Meta: validation failed for "required" tag`+
+		`; .Target.City: validation failed for "min" tag`+
+		`; .Items[0]: validation failed for "max" tag`+
+		`; .Printer: validation failed for "required" tag`)
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	type Address struct {
+		City string `validate:"min:2"`
+		Zip  string `validate:"len:5"`
+	}
+	type Audit struct {
+		By string
+	}
+	type customer struct {
+		Address `validate:"required"`
+		*Audit  `validate:"required"`
+		Name    string `validate:"min:1"`
+	}
+	valid := customer{Address{"Oslo", "01234"}, &Audit{}, "Ann"}
+	assert.NoError(t, Validate(valid))
+
+	err := Validate(customer{Name: "Bob"})
+	assert.EqualError(t, err, `.Address: validation failed for "required" tag`+
+		`; .Address.City: validation failed for "min" tag`+
+		`; .Address.Zip: validation failed for "len" tag`+
+		`; .Audit: validation failed for "required" tag`)
+
+	err = Validate(customer{Address{"X", "01234"}, &Audit{}, ""}, WithPromotedPaths())
+	assert.EqualError(t, err, `.City: validation failed for "min" tag`+
+		`; .Name: validation failed for "min" tag`)
+}
+
+type node struct {
+	Val  int `validate:"min:1"`
+	Next *node
+}
+
+func TestCyclesAndDepth(t *testing.T) {
+	a := &node{Val: 1}
+	b := &node{Val: 0, Next: a}
+	a.Next = b
+	assert.EqualError(t, Validate(a), `.Next.Val: validation failed for "min" tag`)
+
+	var head *node
+	for i := 0; i < 20; i++ {
+		head = &node{Val: 1, Next: head}
+	}
+	assert.NoError(t, Validate(head))
+	assert.NoError(t, Validate(head, WithMaxDepth(21)))
+	assert.Equal(t, ErrMaxDepthExceeded, Validate(head, WithMaxDepth(10)))
+	assert.Equal(t, ErrMaxDepthExceeded, Check(head, WithMaxDepth(10)).Err())
+	assert.NoError(t, Validate(head, WithMaxDepth(0)))
+}
+
+func TestDescriptions(t *testing.T) {
+	type Address struct {
+		Zip  string `validate:"len:5" desc:"Postal code"`
+		City string `validate:"min:2"`
+	}
+	type Customer struct {
+		Name    string   `validate:"min:2" desc:"Customer legal name"`
+		Address Address  `desc:"Billing address"`
+		Tags    []string `validate:"max:3" desc:"Labels"`
+	}
+	err := Validate(Customer{Address: Address{Zip: "1"}, Tags: []string{"long"}})
+	var valErrs ValidationErrors
+	assert.ErrorAs(t, err, &valErrs)
+	var descs []string
+	for _, e := range valErrs {
+		descs = append(descs, e.Description)
+	}
+	assert.Equal(t, []string{"Customer legal name", "Postal code", "Billing address", "Labels"}, descs)
+}
+
+func TestFieldErrors(t *testing.T) {
+	type Item struct {
+		Tags []string `validate:"max:3"`
+	}
+	err := Validate(struct{ Items []Item }{[]Item{{Tags: []string{"ok", "long"}}}})
+	var valErrs ValidationErrors
+	assert.ErrorAs(t, err, &valErrs)
+	assert.Equal(t, FieldError{
+		Err:         errors.New(`.Items[0].Tags[1]: validation failed for "max" tag`),
+		StructField: "Tags",
+		Path:        ".Items[0].Tags[1]",
+		Rule:        "max",
+		Param:       "3",
+		Code:        "VAL_MAX",
+		Value:       "long",
+	}, valErrs[0])
+	assert.Equal(t, valErrs[0].Err.Error(), valErrs[0].Error())
+
+	assert.ErrorAs(t, Var(5, "min:10"), &valErrs)
+	assert.Equal(t, FieldError{
+		Err:   errors.New(`validation failed for "min" tag`),
+		Rule:  "min",
+		Param: "10",
+		Code:  "VAL_MIN",
+		Value: 5,
+	}, valErrs[0])
+}
+
+func TestCustomMessages(t *testing.T) {
+	type Account struct {
+		Password string            `validate:"min:8;msg:password must be at least 8 characters"`
+		Email    string            `validate:"email"`
+		Labels   map[string]string `validate:"keys:alpha;msg:labels must be named with letters"`
+	}
+	err := Validate(Account{Password: "short", Email: "bob", Labels: map[string]string{"a1": "x"}})
+	var valErrs ValidationErrors
+	assert.ErrorAs(t, err, &valErrs)
+	assert.Len(t, valErrs, 3)
+	assert.EqualError(t, valErrs[0], "password must be at least 8 characters")
+	assert.Equal(t, ".Password", valErrs[0].Path)
+	assert.Equal(t, "min", valErrs[0].Rule)
+	assert.EqualError(t, valErrs[1], `.Email: validation failed for "email" tag`)
+	assert.EqualError(t, valErrs[2], "labels must be named with letters")
+	assert.ErrorIs(t, err, RuleFailed("min"))
+
+	assert.EqualError(t, Var("x", "min:2;msg:too short; use 2 or more"), "too short; use 2 or more")
+	assert.NoError(t, Var("xyz", "min:2;msg:too short"))
+}
+
+func TestErrorsPackage(t *testing.T) {
+	type S struct {
+		Name string `validate:"required"`
+		Age  int    `validate:"min:18"`
+	}
+	err := fmt.Errorf("create user: %w", Validate(S{Name: "Bob"}))
+	assert.ErrorIs(t, err, ErrRuleFailed)
+	assert.ErrorIs(t, err, RuleFailed("min"))
+	assert.NotErrorIs(t, err, RuleFailed("required"))
+	assert.NotErrorIs(t, err, ErrInvalidValidatorSyntax)
+
+	var fe FieldError
+	assert.ErrorAs(t, err, &fe)
+	assert.Equal(t, ".Age", fe.Path)
+
+	err = Validate(struct {
+		A int `validate:"min:x"`
+	}{})
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+	assert.NotErrorIs(t, err, ErrRuleFailed)
+
+	err = Validate(struct {
+		A int `validate:"nope:1"`
+	}{})
+	assert.ErrorIs(t, err, ErrInvalidValidatorSyntax)
+	assert.EqualError(t, RuleFailed("min"), `validation failed for "min" tag`)
+}
+
+func TestValidationErrorsQueries(t *testing.T) {
+	type Address struct {
+		Email string `validate:"email"`
+	}
+	type User struct {
+		Email   string `validate:"email;min:12"`
+		Name    string `validate:"min:3"`
+		Address Address
+	}
+	var valErrs ValidationErrors
+	assert.ErrorAs(t, Validate(User{Email: "bob", Name: "al", Address: Address{Email: "x"}}), &valErrs)
+
+	paths := func(errs ValidationErrors) (res []string) {
+		for _, fe := range errs {
+			res = append(res, fe.Path+" "+fe.Rule)
+		}
+		return res
+	}
+	assert.Equal(t, []string{".Email email", ".Email min", ".Address.Email email"}, paths(valErrs.ByField("Email")))
+	assert.Equal(t, []string{".Address.Email email"}, paths(valErrs.ByField("Address.Email")))
+	assert.Equal(t, []string{".Address.Email email"}, paths(valErrs.ByField(".Address.Email")))
+	assert.Equal(t, []string{".Email min", ".Name min"}, paths(valErrs.ByRule("min")))
+	assert.Nil(t, valErrs.ByRule("max"))
+	assert.Equal(t, []string{".Name min"}, paths(valErrs.Filter(func(fe FieldError) bool {
+		return fe.Value == "al"
+	})))
+
+	first, ok := valErrs.First()
+	assert.True(t, ok)
+	assert.Equal(t, ".Email", first.Path)
+	_, ok = ValidationErrors(nil).First()
+	assert.False(t, ok)
+}
+
+func TestValidationErrorsFormat(t *testing.T) {
+	type User struct {
+		Name string  `validate:"min:3"`
+		Age  int     `validate:"min:18"`
+		Nick *string `validate:"required"`
+	}
+	err := Validate(User{Name: "al", Age: 7})
+	assert.EqualError(t, err, `.Name: validation failed for "min" tag; .Age: validation failed for "min" tag; `+
+		`.Nick: validation failed for "required" tag`)
+	assert.Equal(t, err.Error(), fmt.Sprintf("%v", err))
+	assert.Equal(t, `.Name [min:3] value="al": .Name: validation failed for "min" tag
+.Age [min:18] value=7: .Age: validation failed for "min" tag
+.Nick [required]: .Nick: validation failed for "required" tag`, fmt.Sprintf("%+v", err))
+	assert.Equal(t, `"validation failed for \"min\" tag"`, fmt.Sprintf("%q", Var(1, "min:2")))
+	assert.Equal(t, `[min:2] value=1: validation failed for "min" tag`, fmt.Sprintf("%+v", Var(1, "min:2")))
+}
+
+func TestMaxErrors(t *testing.T) {
+	type Row struct {
+		Code string `validate:"len:3;alpha"`
+		Qty  int    `validate:"min:1"`
+	}
+	type Import struct {
+		Rows []Row
+	}
+	rows := make([]Row, 100)
+	var valErrs ValidationErrors
+	assert.ErrorAs(t, Validate(Import{rows}), &valErrs)
+	assert.Len(t, valErrs, 300)
+
+	assert.ErrorAs(t, Validate(Import{rows}, WithMaxErrors(5)), &valErrs)
+	assert.Len(t, valErrs, 5)
+	assert.Equal(t, ".Rows[1].Code", valErrs[3].Path)
+	assert.Equal(t, "len", valErrs[3].Rule)
+
+	assert.ErrorAs(t, Validate(Import{rows}, WithFailFast()), &valErrs)
+	assert.Len(t, valErrs, 1)
+	assert.ErrorAs(t, New(WithMaxErrors(2)).Validate(Import{rows}), &valErrs)
+	assert.Len(t, valErrs, 2)
+	assert.ErrorAs(t, Var("", "len:3;alpha", WithMaxErrors(1)), &valErrs)
+	assert.Len(t, valErrs, 1)
+	assert.NoError(t, Validate(Import{[]Row{{"abc", 1}}}, WithMaxErrors(1)))
+}
+
+func TestValidationErrorsSort(t *testing.T) {
+	errs := ValidationErrors{
+		{Path: ".Items[10].Name", Rule: "min"},
+		{Path: ".Items[2].Name", Rule: "max"},
+		{Path: ".Items[2]", Rule: "required"},
+		{Path: ".Email", Rule: "min"},
+		{Path: ".Email", Rule: "email"},
+		{Path: `.Labels["b"]`, Rule: "len"},
+		{Path: `.Labels["a"]`, Rule: "len"},
+		{Path: "", Rule: "min"},
+	}
+	errs.Sort()
+	var got []string
+	for _, fe := range errs {
+		got = append(got, fe.Path+" "+fe.Rule)
+	}
+	assert.Equal(t, []string{
+		" min",
+		".Email email",
+		".Email min",
+		".Items[2] required",
+		".Items[2].Name max",
+		".Items[10].Name min",
+		`.Labels["a"] len`,
+		`.Labels["b"] len`,
+	}, got)
+
+	pointers := ValidationErrors{{Path: "/items/10"}, {Path: "/items/9/name"}, {Path: "/email"}}
+	pointers.Sort()
+	assert.Equal(t, ValidationErrors{{Path: "/email"}, {Path: "/items/9/name"}, {Path: "/items/10"}}, pointers)
+}
+
+func TestDuplicateErrors(t *testing.T) {
+	type User struct {
+		Name string     `validate:"max:8|trim|max:8"`
+		Tags [][]string `validate:"min:2|lower|min:2"`
+	}
+	u := User{" far too long ", [][]string{{"A", "b"}}}
+	var valErrs ValidationErrors
+	assert.ErrorAs(t, Validate(u), &valErrs)
+	var got []string
+	for _, fe := range valErrs {
+		got = append(got, fe.Path+" "+fe.Rule)
+	}
+	assert.Equal(t, []string{".Name max", ".Tags[0][0] min", ".Tags[0][1] min"}, got)
+
+	assert.ErrorAs(t, Validate(u, WithDuplicateErrors()), &valErrs)
+	assert.Len(t, valErrs, 6)
+}
@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// deprecatedRule warns about values listed by its parameter, e.g.
+// "in:v1,v2;deprecated:v1", so that they can be phased out: the values
+// are accepted, and reported by Result.Warnings.
+var deprecatedRule = rule{
+	assertInt: func(val int64, p param) (bool, error) {
+		return !listed(strconv.FormatInt(val, 10), p), nil
+	},
+	assertUint: func(val uint64, p param) (bool, error) {
+		return !listed(strconv.FormatUint(val, 10), p), nil
+	},
+	assertFloat: func(val float64, p param) (bool, error) {
+		for _, elem := range p.values() {
+			if elem.fErr == nil && elem.f == val {
+				return false, nil
+			}
+		}
+		return true, nil
+	},
+	assertStr: func(val string, p param) (bool, error) {
+		return !listed(val, p), nil
+	},
+	explain: func(fl fieldLevel, p param) string {
+		return fmt.Sprintf("%v is deprecated", interfaceOf(fl.field))
+	},
+	warn: true,
+}
+
+// listed reports whether s is an element of the set parameter p.
+func listed(s string, p param) bool {
+	for _, elem := range p.values() {
+		if s == elem.s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecated(t *testing.T) {
+	type Client struct {
+		API     string  `validate:"in:v1,v2,v3;deprecated:v1,v2"`
+		Level   int     `validate:"min:1;deprecated:1"`
+		Ratio   float64 `validate:"deprecated:0.5"`
+		Version string  `validate:"deprecated:v1"`
+	}
+	res := Check(Client{API: "v1", Level: 1, Ratio: 0.5, Version: "v2"})
+	assert.True(t, res.Valid())
+	assert.NoError(t, res.Err())
+	assert.Equal(t, Passed, res.Outcome(".API"))
+	warnings := res.Warnings()
+	assert.Len(t, warnings, 3)
+	assert.EqualError(t, warnings[0], `.API: validation failed for "deprecated" tag: v1 is deprecated`)
+	assert.Equal(t, "deprecated", warnings[0].Rule)
+	assert.Equal(t, "v1,v2", warnings[0].Param)
+	assert.Equal(t, ".Level", warnings[1].Path)
+	assert.Equal(t, ".Ratio", warnings[2].Path)
+
+	res = Check(Client{API: "v4", Level: 2})
+	assert.False(t, res.Valid())
+	assert.Empty(t, res.Warnings())
+	assert.NoError(t, Var("v1", "deprecated:v1"))
+
+	type V1 struct {
+		API string `validate:"in:v1,v2"`
+	}
+	type V2 struct {
+		API string `validate:"in:v1,v2;deprecated:v1"`
+	}
+	changes, err := CompareRules(V1{}, V2{})
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestWarnModifier(t *testing.T) {
+	type Item struct {
+		SKU string
+	}
+	type Order struct {
+		Quantity int    `validate:"max:1000;warn:max:100"`
+		Note     string `validate:"warn:required"`
+		Items    []Item `validate:"warn:unique_by:SKU"`
+	}
+	res := Check(Order{Quantity: 500, Items: []Item{{"a"}, {"b"}, {"a"}}})
+	assert.True(t, res.Valid())
+	assert.Equal(t, Passed, res.Outcome(".Quantity"))
+	warnings := res.Warnings()
+	if assert.Len(t, warnings, 3) {
+		assert.EqualError(t, warnings[0], `.Quantity: validation failed for "max" tag`)
+		assert.Equal(t, "100", warnings[0].Param)
+		assert.Equal(t, ".Note", warnings[1].Path)
+		assert.Equal(t, "required", warnings[1].Rule)
+		assert.Equal(t, ".Items[2]", warnings[2].Path)
+	}
+
+	res = Check(Order{Quantity: 5000, Note: "rush"})
+	assert.EqualError(t, res.Err(), `.Quantity: validation failed for "max" tag`)
+	assert.Len(t, res.Warnings(), 1)
+
+	assert.NoError(t, Var(500, "warn:max:100"))
+	assert.ErrorIs(t, Var(500, "warn:"), ErrInvalidValidatorSyntax)
+
+	type V1 struct {
+		Quantity int `validate:"max:1000"`
+	}
+	type V2 struct {
+		Quantity int `validate:"max:1000;warn:max:100"`
+	}
+	changes, err := CompareRules(V1{}, V2{})
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
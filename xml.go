@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// DecodeAndValidateXML decodes an XML document from r into v, a pointer to
+// a struct, and validates it with the package default Validator.
+func DecodeAndValidateXML(r io.Reader, v any, opts ...Option) error {
+	return defaultValidator.DecodeAndValidateXML(r, v, opts...)
+}
+
+// DecodeAndValidateXML decodes an XML document from r into v, a pointer to
+// a struct, and validates it. Error paths name fields as in the document,
+// see WithXMLFieldNames. With WithNormalize, normalized values are written
+// to the decoded struct.
+func (v *Validator) DecodeAndValidateXML(r io.Reader, s any, opts ...Option) error {
+	if err := xml.NewDecoder(r).Decode(s); err != nil {
+		return err
+	}
+	sVal := reflect.ValueOf(s)
+	if sVal.Kind() != reflect.Pointer || sVal.IsNil() {
+		return ErrNotStruct
+	}
+	return v.Validate(s, append([]Option{WithXMLFieldNames()}, opts...)...)
+}
+
+// xmlFieldName returns the name of field in XML, following the rules of
+// encoding/xml. Fields without an XML name of their own keep their Go name.
+func xmlFieldName(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("xml")
+	if !ok || tag == "-" {
+		return field.Name
+	}
+	name, flags, _ := strings.Cut(tag, ",")
+	if i := strings.LastIndexByte(name, ' '); i >= 0 {
+		// Drop the namespace of "ns name".
+		name = name[i+1:]
+	}
+	if name == "" {
+		name = field.Name
+	}
+	for _, flag := range strings.Split(flags, ",") {
+		if flag == "attr" {
+			return "@" + name
+		}
+	}
+	return name
+}
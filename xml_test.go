@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeAndValidateXML(t *testing.T) {
+	type url struct {
+		Loc      string  `xml:"loc" validate:"min:8"`
+		Priority float64 `xml:"priority,omitempty" validate:"max:1"`
+		Lang     string  `xml:"lang,attr" validate:"len:2"`
+		ID       int     `xml:",attr" validate:"min:1"`
+	}
+	type urlset struct {
+		XMLName xml.Name `xml:"urlset"`
+		URLs    []url    `xml:"url"`
+	}
+	var set urlset
+	err := DecodeAndValidateXML(strings.NewReader(`<urlset>
+		<url lang="en" ID="1"><loc>https://example.com/</loc><priority>0.5</priority></url>
+		<url lang="eng" ID="0"><loc>/about</loc><priority>2</priority></url>
+	</urlset>`), &set)
+	assert.EqualError(t, err, `.url[1].loc: validation failed for "min" tag`+
+		`; .url[1].priority: validation failed for "max" tag`+
+		`; .url[1]."@lang": validation failed for "len" tag`+
+		`; .url[1]."@ID": validation failed for "min" tag`)
+	assert.Len(t, set.URLs, 2)
+
+	err = Validate(set)
+	assert.ErrorContains(t, err, `.URLs[1].Loc: validation failed`)
+
+	var contact struct {
+		Email string `xml:"email" validate:"trim|lower|email"`
+	}
+	err = DecodeAndValidateXML(strings.NewReader(`<contact><email> Ann@Example.com </email></contact>`), &contact, WithNormalize())
+	assert.NoError(t, err)
+	assert.Equal(t, "ann@example.com", contact.Email)
+
+	err = DecodeAndValidateXML(strings.NewReader(`<urlset><url>`), &set)
+	assert.ErrorContains(t, err, "XML syntax error")
+}